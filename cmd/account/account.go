@@ -15,6 +15,13 @@ func NewAccountCmd(svc *service.Service) *cobra.Command {
 
 	accountCmd.AddCommand(NewCreateCmd(svc))
 	accountCmd.AddCommand(NewListCmd(svc))
+	accountCmd.AddCommand(NewRenameCmd(svc))
+	accountCmd.AddCommand(NewMoveCmd(svc))
+	accountCmd.AddCommand(NewArchiveCmd(svc))
+	accountCmd.AddCommand(NewUnarchiveCmd(svc))
+	accountCmd.AddCommand(NewDeleteCmd(svc))
+	accountCmd.AddCommand(NewMergeCmd(svc))
+	accountCmd.AddCommand(NewRegisterCmd(svc))
 
 	return accountCmd
 }
@@ -0,0 +1,67 @@
+package account
+
+import (
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type archiveFlags struct {
+	Force bool
+}
+
+type archiveRunner struct {
+	svc   *service.Service
+	flags *archiveFlags
+}
+
+func NewArchiveCmd(svc *service.Service) *cobra.Command {
+	flags := &archiveFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "archive <name>",
+		Short: "Hide an account and its sub-accounts from account pickers",
+		Long: `Archive an account you no longer use, e.g. a closed bank account. Archived
+accounts and their sub-accounts are left out of the account pickers and
+'kea account list', but their transactions still show up in reports.
+
+Only accounts with a zero balance can be archived unless --force is given.
+
+Examples:
+  kea account archive Assets:OldBank
+  kea account archive Liabilities:OldCard --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &archiveRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&flags.Force, "force", "f", false, "Archive even if the account still has a balance")
+
+	return cmd
+}
+
+func (r *archiveRunner) Run(args []string) error {
+	accounts, err := r.svc.Account.ArchiveAccount(args[0], r.flags.Force)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Archived %s\n", args[0])
+	printAffected(accounts, args[0])
+	return nil
+}
+
+// printAffected lists the accounts other than name that changed along with it.
+func printAffected(accounts []*model.Account, name string) {
+	for _, acc := range accounts {
+		if acc.Name != name {
+			pterm.Info.Printf("Also updated: %s\n", acc.Name)
+		}
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hance08/kea/internal/commodity"
 	"github.com/hance08/kea/internal/model"
 	"github.com/hance08/kea/internal/service"
 	"github.com/hance08/kea/internal/store"
 	"github.com/hance08/kea/internal/ui/prompts"
 	"github.com/hance08/kea/internal/ui/views"
-	"github.com/hance08/kea/internal/utils"
 	"github.com/hance08/kea/internal/validation"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
@@ -132,9 +132,9 @@ func (r *createRunner) runFromFlags(flags *createFlags) error {
 	}
 
 	// Handle balance
-	balance, err := utils.ParseToCents(flags.BalanceStr)
+	balance, err := commodity.Parse(flags.BalanceStr, r.currency)
 	if err != nil {
-		return fmt.Errorf("invalid balance format '%s': please enter a number (e.g. 100 or 100.50)", flags.BalanceStr)
+		return fmt.Errorf("invalid balance '%s' for %s: %w", flags.BalanceStr, r.currency, err)
 	}
 
 	r.balance = balance
@@ -295,6 +295,13 @@ func (r *createRunner) buildFromParentName(parentName, currency string) error {
 		return err
 	}
 
+	if currency != "" {
+		if err := r.validator.ValidateCurrency(currency); err != nil {
+			return err
+		}
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+	}
+
 	r.applyParentSettings(parentAccount, currency)
 	return nil
 }
@@ -365,12 +372,20 @@ func (r *createRunner) promptCurrency() (string, error) {
 }
 
 func (r *createRunner) promptBalance() (int64, error) {
-	balanceInput, err := prompts.PromptInitialBalance(r.validator.ValidateInitialBalance)
+	validator := func(input string) error {
+		if err := r.validator.ValidateInitialBalance(input); err != nil {
+			return err
+		}
+		_, err := commodity.Parse(input, r.currency)
+		return err
+	}
+
+	balanceInput, err := prompts.PromptInitialBalance(validator)
 	if err != nil {
 		return 0, err
 	}
 
-	return utils.ParseToCents(balanceInput)
+	return commodity.Parse(balanceInput, r.currency)
 }
 
 func (r *createRunner) promptDescription() (string, error) {
@@ -0,0 +1,76 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/prompts"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type deleteFlags struct {
+	ReassignTo string
+	Yes        bool
+}
+
+type deleteRunner struct {
+	svc   *service.Service
+	flags *deleteFlags
+}
+
+func NewDeleteCmd(svc *service.Service) *cobra.Command {
+	flags := &deleteFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete an account",
+		Long: `Delete an account. An account that has splits, sub-accounts or
+categorization rules can only be deleted by moving them to another account of
+the same type and currency with --reassign-to, which works like
+'kea account merge'. This action cannot be undone.
+
+Examples:
+  kea account delete Expenses:Unused
+  kea account delete Expenses:Dining --reassign-to Expenses:Food`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &deleteRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.ReassignTo, "reassign-to", "", "Move splits, sub-accounts and rules to this account before deleting")
+	cmd.Flags().BoolVarP(&flags.Yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+func (r *deleteRunner) Run(args []string) error {
+	message := fmt.Sprintf("Do you want to delete %s?", args[0])
+	if r.flags.ReassignTo != "" {
+		message = fmt.Sprintf("Do you want to move everything in %s to %s and delete it?", args[0], r.flags.ReassignTo)
+	}
+
+	if !r.flags.Yes {
+		confirmation, err := prompts.PromptConfirm(message, false)
+		if err != nil {
+			return err
+		}
+		if !confirmation {
+			pterm.Info.Println("Deletion cancelled")
+			return nil
+		}
+	}
+
+	removal, err := r.svc.Account.DeleteAccount(args[0], r.flags.ReassignTo)
+	if err != nil {
+		return err
+	}
+
+	return views.RenderAccountRemoval(removal, r.svc.Config)
+}
@@ -12,6 +12,8 @@ import (
 type listFlags struct {
 	Type       string
 	ShowHidden bool
+	Tree       bool
+	Depth      int
 }
 
 type listRunner struct {
@@ -27,7 +29,15 @@ func NewListCmd(svc *service.Service) *cobra.Command {
 		Aliases: []string{"ls", "l"},
 		Short:   "List all accounts with their balances.",
 		Long: `List all accounts in the system with their current balances.
-You can filter by account type or show hidden accounts.`,
+You can filter by account type or show hidden accounts.
+
+With --tree, accounts are indented under their parents and each one shows
+its own balance next to the total of its sub-accounts. --depth, which needs
+--tree, collapses deeper levels into their parent's total.
+
+Examples:
+  kea account list --tree
+  kea account list --tree --depth 2`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runner := &listRunner{
 				svc:   svc,
@@ -39,11 +49,19 @@ You can filter by account type or show hidden accounts.`,
 
 	cmd.Flags().StringVarP(&flags.Type, "type", "t", "", "Filter accounts by type (A, L, C, R, E)")
 	cmd.Flags().BoolVar(&flags.ShowHidden, "show-hidden", false, "Show hidden accounts")
+	cmd.Flags().BoolVar(&flags.Tree, "tree", false, "Show accounts as a tree with subtree totals")
+	cmd.Flags().IntVar(&flags.Depth, "depth", 0, "Number of name levels to show in the tree, e.g. 2 for Assets:Bank (0 shows all)")
 
 	return cmd
 }
 
 func (r *listRunner) Run() error {
+	if r.flags.Depth < 0 {
+		return fmt.Errorf("--depth must not be negative")
+	}
+	if r.flags.Depth > 0 && !r.flags.Tree {
+		return fmt.Errorf("--depth only applies to --tree")
+	}
 
 	var accounts []*model.Account
 	var err error
@@ -62,7 +80,25 @@ func (r *listRunner) Run() error {
 		accounts = r.filterHiddenAccounts(accounts)
 	}
 
-	if err := views.NewAccountListView().Render(accounts, r.svc.Account.GetAccountBalanceFormatted); err != nil {
+	converter, err := r.svc.Price.NewConverter()
+	if err != nil {
+		return err
+	}
+
+	if r.flags.Tree {
+		tree, err := r.svc.Account.GetAccountTree(accounts, converter)
+		if err != nil {
+			return fmt.Errorf("failed to get balances: %w", err)
+		}
+		return views.NewAccountListView().RenderTree(tree, r.flags.Depth)
+	}
+
+	balances, err := r.svc.Account.GetAccountBalances(accounts, converter)
+	if err != nil {
+		return fmt.Errorf("failed to get balances: %w", err)
+	}
+
+	if err := views.NewAccountListView().Render(balances, converter.Target()); err != nil {
 		return err
 	}
 
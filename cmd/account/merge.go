@@ -0,0 +1,66 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/prompts"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type mergeFlags struct {
+	Yes bool
+}
+
+type mergeRunner struct {
+	svc   *service.Service
+	flags *mergeFlags
+}
+
+func NewMergeCmd(svc *service.Service) *cobra.Command {
+	flags := &mergeFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "merge <src> <dst>",
+		Short: "Merge one account into another",
+		Long: `Move all splits and sub-accounts of <src> to <dst> and delete <src>. Both
+accounts must have the same type and currency. Categorization rules using
+<src> are changed to use <dst>. This action cannot be undone.
+
+Example: kea account merge Expenses:Dining Expenses:Food`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &mergeRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&flags.Yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+func (r *mergeRunner) Run(args []string) error {
+	if !r.flags.Yes {
+		confirmation, err := prompts.PromptConfirm(fmt.Sprintf("Do you want to merge %s into %s?", args[0], args[1]), false)
+		if err != nil {
+			return err
+		}
+		if !confirmation {
+			pterm.Info.Println("Merge cancelled")
+			return nil
+		}
+	}
+
+	removal, err := r.svc.Account.MergeAccount(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	return views.RenderAccountRemoval(removal, r.svc.Config)
+}
@@ -0,0 +1,51 @@
+package account
+
+import (
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type moveFlags struct {
+	Parent string
+}
+
+type moveRunner struct {
+	svc   *service.Service
+	flags *moveFlags
+}
+
+func NewMoveCmd(svc *service.Service) *cobra.Command {
+	flags := &moveFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "move <name>",
+		Short: "Move an account and its sub-accounts under another parent",
+		Long: `Move an account under another account of the same type, or to the top level
+with a root name such as Assets. Sub-accounts move with it.
+
+Example: kea account move Assets:Savings --parent Assets:Bank`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &moveRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Parent, "parent", "p", "", "New parent account full name, or a root such as Assets")
+	_ = cmd.MarkFlagRequired("parent")
+
+	return cmd
+}
+
+func (r *moveRunner) Run(args []string) error {
+	renames, err := r.svc.Account.MoveAccount(args[0], r.flags.Parent)
+	if err != nil {
+		return err
+	}
+
+	return views.RenderAccountRenames(renames, r.svc.Config)
+}
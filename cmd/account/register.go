@@ -0,0 +1,71 @@
+package account
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type registerFlags struct {
+	From string
+	To   string
+}
+
+type registerRunner struct {
+	svc   *service.Service
+	flags *registerFlags
+}
+
+func NewRegisterCmd(svc *service.Service) *cobra.Command {
+	flags := &registerFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "register <name>",
+		Short: "Show every split of an account with a running balance",
+		Long: `List the splits of an account in date order with the other accounts of
+each transaction, the memo, debit and credit columns and the balance after
+each line. With --from, the register starts from the balance of all earlier
+splits.
+
+Example: kea account register Assets:Bank:Checking --from 2025-01-01 --to 2025-03-31`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &registerRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.From, "from", "", "Start date (YYYY-MM-DD), default is the first split")
+	cmd.Flags().StringVar(&flags.To, "to", "", "End date (YYYY-MM-DD), default is the last split")
+
+	return cmd
+}
+
+func (r *registerRunner) Run(args []string) error {
+	var from, to int64
+	var err error
+
+	if r.flags.From != "" {
+		if from, err = utils.ParseDateStart(r.flags.From); err != nil {
+			return fmt.Errorf("invalid --from: %w", err)
+		}
+	}
+	if r.flags.To != "" {
+		if to, err = utils.ParseDateEnd(r.flags.To); err != nil {
+			return fmt.Errorf("invalid --to: %w", err)
+		}
+	}
+
+	register, err := r.svc.Account.GetRegister(args[0], from, to)
+	if err != nil {
+		return fmt.Errorf("failed to build register: %w", err)
+	}
+
+	return views.RenderAccountRegister(register)
+}
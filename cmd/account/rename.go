@@ -0,0 +1,38 @@
+package account
+
+import (
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type renameRunner struct {
+	svc *service.Service
+}
+
+func NewRenameCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename an account and its sub-accounts",
+		Long: `Rename an account, keeping it under the same parent. The new name is either
+the new last segment or the full new name. Sub-accounts are renamed with it.
+
+Examples:
+  kea account rename Assets:Bank Checking
+  kea account rename Assets:Bank Assets:Checking`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &renameRunner{svc: svc}
+			return runner.Run(args)
+		},
+	}
+}
+
+func (r *renameRunner) Run(args []string) error {
+	renames, err := r.svc.Account.RenameAccount(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	return views.RenderAccountRenames(renames, r.svc.Config)
+}
@@ -0,0 +1,38 @@
+package account
+
+import (
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type unarchiveRunner struct {
+	svc *service.Service
+}
+
+func NewUnarchiveCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unarchive <name>",
+		Short: "Make an archived account selectable again",
+		Long: `Unarchive an account and its sub-accounts. Archived parent accounts are
+unarchived as well so the account can be picked again.
+
+Example: kea account unarchive Assets:OldBank`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &unarchiveRunner{svc: svc}
+			return runner.Run(args)
+		},
+	}
+}
+
+func (r *unarchiveRunner) Run(args []string) error {
+	accounts, err := r.svc.Account.UnarchiveAccount(args[0])
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Unarchived %s\n", args[0])
+	printAffected(accounts, args[0])
+	return nil
+}
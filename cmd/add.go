@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hance08/kea/internal/commodity"
 	"github.com/hance08/kea/internal/constants"
 	"github.com/hance08/kea/internal/model"
 	"github.com/hance08/kea/internal/service"
@@ -22,6 +23,7 @@ type addFlags struct {
 	To        string
 	Status    string
 	Timestamp string
+	Rate      string
 }
 
 type addRunner struct {
@@ -48,6 +50,12 @@ func NewAddCmd(svc *service.Service) *cobra.Command {
 	# Quick mode with flags
 	kea add --desc "Buy Coffee" --amount 150 --from "Assets:Cash" --to "Expenses:Food:Coffee"
 	
+	# Let categorization rules pick the destination account
+	kea add --desc "Netflix" --amount 15.99 --from "Liabilities:CreditCard"
+
+	# Transfer between currencies, 1 USD = 0.92 EUR
+	kea add --desc "Exchange" --amount 100 --from "Assets:Cash" --to "Assets:Bank:EUR" --rate 0.92
+
 	# With pending status (default is cleared)
 	kea add --desc "Pending cost" --amount 500 --from "Assets:Bank" --to "Expenses:Shopping" --status pending`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -62,9 +70,10 @@ func NewAddCmd(svc *service.Service) *cobra.Command {
 	cmd.Flags().StringVarP(&flags.Desc, "desc", "d", "", "Transaction description")
 	cmd.Flags().StringVarP(&flags.Amount, "amount", "a", "", "Transaction amount (e.g., 150 or 150.50)")
 	cmd.Flags().StringVarP(&flags.From, "from", "f", "", "Source account (where money comes from)")
-	cmd.Flags().StringVarP(&flags.To, "to", "t", "", "Destination account (where money goes to)")
+	cmd.Flags().StringVarP(&flags.To, "to", "t", "", "Destination account (where money goes to), picked by rules if omitted")
 	cmd.Flags().StringVarP(&flags.Status, "status", "s", "cleared", "Transaction status: pending or cleared")
 	cmd.Flags().StringVar(&flags.Timestamp, "date", "", "Transaction date (YYYY-MM-DD), default is today")
+	cmd.Flags().StringVar(&flags.Rate, "rate", "", "Exchange rate from the source to the destination currency")
 
 	return cmd
 }
@@ -92,7 +101,7 @@ func (r *addRunner) Run() error {
 	pterm.Success.Printf("Transaction created successfully! (ID: %d)\n", txID)
 
 	// Display transaction summary
-	if err := views.RenderTransactionSummary(input); err != nil {
+	if err := views.RenderTransactionSummary(txID, input); err != nil {
 		return err
 	}
 
@@ -102,20 +111,39 @@ func (r *addRunner) Run() error {
 func (r *addRunner) flagsMode() (int64, service.TransactionInput, error) {
 
 	// Flag mode: validate all required flags
-	if r.flags.Amount == "" || r.flags.From == "" || r.flags.To == "" {
-		return 0, service.TransactionInput{}, fmt.Errorf("when using flags, --amount, --from, and --to are all required")
+	if r.flags.Amount == "" || r.flags.From == "" {
+		return 0, service.TransactionInput{}, fmt.Errorf("when using flags, --amount and --from are required")
 	}
 
 	if r.flags.Desc == "" {
 		r.flags.Desc = "-"
 	}
 
-	// Parse amount
-	amountCents, err := utils.ParseToCents(r.flags.Amount)
+	// Parse amount in the source account's currency
+	from, err := r.svc.Account.GetAccountByName(r.flags.From)
+	if err != nil {
+		return 0, service.TransactionInput{}, err
+	}
+
+	amountCents, err := commodity.Parse(r.flags.Amount, from.Currency)
 	if err != nil {
 		return 0, service.TransactionInput{}, fmt.Errorf("invalid amount: %w", err)
 	}
 
+	// Resolve destination account via categorization rules when omitted
+	if r.flags.To == "" {
+		to, rule, err := r.svc.Rule.Categorize(r.flags.Desc, amountCents, r.flags.From)
+		if err != nil {
+			return 0, service.TransactionInput{}, fmt.Errorf("failed to categorize transaction: %w", err)
+		}
+		if rule != nil {
+			pterm.Info.Printf("Rule #%d matched, using account: %s\n", rule.ID, to)
+		} else {
+			pterm.Info.Printf("No rule matched, using account: %s\n", to)
+		}
+		r.flags.To = to
+	}
+
 	// Parse status
 	status := 1 // Default: cleared
 	if strings.ToLower(r.flags.Status) == "pending" {
@@ -134,20 +162,7 @@ func (r *addRunner) flagsMode() (int64, service.TransactionInput, error) {
 		timestamp = time.Now().Unix()
 	}
 
-	txID, input, err := r.svc.Transaction.CreateSimpleTransaction(
-		r.flags.From,
-		r.flags.To,
-		amountCents,
-		r.flags.Desc,
-		timestamp,
-		status,
-	)
-
-	if err != nil {
-		return 0, service.TransactionInput{}, err
-	}
-
-	return txID, input, nil
+	return r.createTransaction(r.flags.From, r.flags.To, amountCents, r.flags.Rate, r.flags.Desc, timestamp, status)
 }
 
 func (r *addRunner) interactiveMode() (int64, service.TransactionInput, error) {
@@ -187,11 +202,6 @@ func (r *addRunner) interactiveMode() (int64, service.TransactionInput, error) {
 		return 0, service.TransactionInput{}, fmt.Errorf("amount is required")
 	}
 
-	amountCents, err := utils.ParseToCents(amountStr)
-	if err != nil {
-		return 0, service.TransactionInput{}, fmt.Errorf("invalid amount format: %w", err)
-	}
-
 	uiConfigs := map[string]struct{ Src, Dst string }{
 		constants.ModeExpense:  {"Payment Source:", "Expense Type:"},
 		constants.ModeIncome:   {"Revenue Type:", "Deposit To:"},
@@ -219,6 +229,12 @@ func (r *addRunner) interactiveMode() (int64, service.TransactionInput, error) {
 		return 0, service.TransactionInput{}, err
 	}
 
+	// The amount is in the source account's currency
+	amountCents, err := commodity.Parse(amountStr, accountCurrency(accounts, fromAccount))
+	if err != nil {
+		return 0, service.TransactionInput{}, fmt.Errorf("invalid amount format: %w", err)
+	}
+
 	// Step 6: Transaction status
 	statusStr, err := prompts.PromptTransactionStatus("Cleared")
 	if err != nil {
@@ -242,20 +258,48 @@ func (r *addRunner) interactiveMode() (int64, service.TransactionInput, error) {
 	}
 	timestamp := t.Unix()
 
-	txID, input, err := r.svc.Transaction.CreateSimpleTransaction(
-		fromAccount,
-		toAccount,
-		amountCents,
-		description,
-		timestamp,
-		status,
-	)
+	// Step 8: Exchange rate when the accounts use different currencies
+	rate := ""
+	fromCurrency, toCurrency := accountCurrency(accounts, fromAccount), accountCurrency(accounts, toAccount)
+	if fromCurrency != toCurrency {
+		rate, err = prompts.PromptInput(
+			fmt.Sprintf("Exchange rate (%s per 1 %s):", toCurrency, fromCurrency),
+			"",
+			func(s string) error {
+				_, err := utils.ParseRate(s)
+				return err
+			},
+		)
+		if err != nil {
+			return 0, service.TransactionInput{}, err
+		}
+	}
+
+	return r.createTransaction(fromAccount, toAccount, amountCents, rate, description, timestamp, status)
+}
+
+// createTransaction records a simple transfer, converting between currencies
+// when a rate is given.
+func (r *addRunner) createTransaction(from, to string, amount int64, rateStr, desc string, timestamp int64, status int) (int64, service.TransactionInput, error) {
+	if rateStr == "" {
+		return r.svc.Transaction.CreateSimpleTransaction(from, to, amount, desc, timestamp, status)
+	}
 
+	rate, err := utils.ParseRate(rateStr)
 	if err != nil {
 		return 0, service.TransactionInput{}, err
 	}
 
-	return txID, input, nil
+	return r.svc.Transaction.CreateConversionTransaction(from, to, amount, rate, desc, timestamp, status)
+}
+
+func accountCurrency(accounts []*model.Account, name string) string {
+	for _, acc := range accounts {
+		if acc.Name == name {
+			return acc.Currency
+		}
+	}
+	return ""
 }
 
 // r.selectAccount filters accounts by type and displays them with optional balance
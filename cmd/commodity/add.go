@@ -0,0 +1,52 @@
+package commodity
+
+import (
+	"strings"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type addFlags struct {
+	Precision int
+}
+
+type addRunner struct {
+	svc   *service.Service
+	flags *addFlags
+}
+
+func NewAddCmd(svc *service.Service) *cobra.Command {
+	flags := &addFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "add <code>",
+		Aliases: []string{"a"},
+		Short:   "Register a commodity or set its precision",
+		Long: `Register a commodity with the number of decimal places its amounts use.
+
+Example: kea commodity add ETH --precision 8`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &addRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().IntVarP(&flags.Precision, "precision", "p", 2, "Number of decimal places")
+
+	return cmd
+}
+
+func (r *addRunner) Run(args []string) error {
+	if err := r.svc.Commodity.SetPrecision(args[0], r.flags.Precision); err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("%s registered with %d decimal places\n", strings.ToUpper(args[0]), r.flags.Precision)
+	return nil
+}
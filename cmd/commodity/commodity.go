@@ -0,0 +1,25 @@
+package commodity
+
+import (
+	"github.com/hance08/kea/internal/service"
+	"github.com/spf13/cobra"
+)
+
+func NewCommodityCmd(svc *service.Service) *cobra.Command {
+	commodityCmd := &cobra.Command{
+		Use:     "commodity",
+		Aliases: []string{"cur"},
+		Short:   "Manage currencies and their decimal precision",
+		Long: `Manage the commodity registry that sets how many decimal places
+amounts in each currency have, e.g. 2 for USD, 0 for JPY, 3 for KWD.
+
+Currencies that are not registered use 2 decimal places. Register a new
+currency before creating accounts in it, since the precision cannot change
+once amounts are stored.`,
+	}
+
+	commodityCmd.AddCommand(NewAddCmd(svc))
+	commodityCmd.AddCommand(NewListCmd(svc))
+
+	return commodityCmd
+}
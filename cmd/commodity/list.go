@@ -0,0 +1,34 @@
+package commodity
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type listRunner struct {
+	svc *service.Service
+}
+
+func NewListCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "l"},
+		Short:   "List registered commodities",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &listRunner{svc: svc}
+			return runner.Run()
+		},
+	}
+}
+
+func (r *listRunner) Run() error {
+	commodities, err := r.svc.Commodity.ListCommodities()
+	if err != nil {
+		return fmt.Errorf("failed to get commodities: %w", err)
+	}
+
+	return views.NewCommodityListView().Render(commodities)
+}
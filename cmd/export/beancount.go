@@ -0,0 +1,46 @@
+package export
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hance08/kea/internal/export"
+	"github.com/hance08/kea/internal/service"
+	"github.com/spf13/cobra"
+)
+
+type beancountRunner struct {
+	svc *service.Service
+	cmd *cobra.Command
+}
+
+func NewBeancountCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:     "beancount",
+		Aliases: []string{"bean"},
+		Short:   "Export to Beancount format",
+		Long: `Export all accounts and transactions to Beancount format.
+
+Every account gets an open directive dated at its first split. Revenue is
+mapped to Beancount's Income root, and account name segments are converted to
+Beancount's rules (capitalized, no spaces).
+
+Example: kea export beancount > kea.beancount`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &beancountRunner{
+				svc: svc,
+				cmd: cmd,
+			}
+			return runner.Run()
+		},
+	}
+}
+
+func (r *beancountRunner) Run() error {
+	journal, err := r.svc.Export.GetJournal(math.MinInt64, math.MaxInt64)
+	if err != nil {
+		return fmt.Errorf("failed to load journal: %w", err)
+	}
+
+	return export.WriteBeancount(r.cmd.OutOrStdout(), journal, r.svc.Config.Defaults.Currency)
+}
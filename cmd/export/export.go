@@ -0,0 +1,44 @@
+package export
+
+import (
+	"math"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewExportCmd(svc *service.Service) *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:     "export",
+		Aliases: []string{"ex"},
+		Short:   "Export the ledger to plain-text accounting formats",
+		Long:    `Export the ledger to plain-text accounting formats. Output is written to stdout.`,
+	}
+
+	exportCmd.AddCommand(NewLedgerCmd(svc))
+	exportCmd.AddCommand(NewBeancountCmd(svc))
+
+	return exportCmd
+}
+
+// parseRange converts optional --from/--to dates into an inclusive timestamp
+// range; an empty bound means unbounded.
+func parseRange(from, to string) (int64, int64, error) {
+	startTime := int64(math.MinInt64)
+	endTime := int64(math.MaxInt64)
+
+	var err error
+	if from != "" {
+		if startTime, err = utils.ParseDateStart(from); err != nil {
+			return 0, 0, err
+		}
+	}
+	if to != "" {
+		if endTime, err = utils.ParseDateEnd(to); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return startTime, endTime, nil
+}
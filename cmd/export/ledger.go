@@ -0,0 +1,61 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/export"
+	"github.com/hance08/kea/internal/service"
+	"github.com/spf13/cobra"
+)
+
+type ledgerFlags struct {
+	From string
+	To   string
+}
+
+type ledgerRunner struct {
+	svc   *service.Service
+	flags *ledgerFlags
+	cmd   *cobra.Command
+}
+
+func NewLedgerCmd(svc *service.Service) *cobra.Command {
+	flags := &ledgerFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "ledger",
+		Aliases: []string{"hledger"},
+		Short:   "Export to Ledger-CLI / hledger journal format",
+		Long: `Export transactions to Ledger-CLI / hledger journal format.
+Pending transactions are marked with '!', cleared and reconciled ones with '*'.
+
+Example: kea export ledger --from 2025-01-01 > kea.journal`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &ledgerRunner{
+				svc:   svc,
+				flags: flags,
+				cmd:   cmd,
+			}
+			return runner.Run()
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.From, "from", "", "Start date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.To, "to", "", "End date (YYYY-MM-DD)")
+
+	return cmd
+}
+
+func (r *ledgerRunner) Run() error {
+	startTime, endTime, err := parseRange(r.flags.From, r.flags.To)
+	if err != nil {
+		return err
+	}
+
+	journal, err := r.svc.Export.GetJournal(startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("failed to load journal: %w", err)
+	}
+
+	return export.WriteLedger(r.cmd.OutOrStdout(), journal)
+}
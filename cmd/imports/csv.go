@@ -0,0 +1,106 @@
+package imports
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hance08/kea/internal/importer"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type csvFlags struct {
+	Profile string
+	Account string
+	To      string
+}
+
+type csvRunner struct {
+	svc   *service.Service
+	flags *csvFlags
+}
+
+func NewCSVCmd(svc *service.Service) *cobra.Command {
+	flags := &csvFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "csv <file>",
+		Short: "Import a bank CSV export using a column-mapping profile",
+		Long: `Import a bank CSV export using a profile from the config file.
+
+A profile maps the CSV columns to transaction fields, e.g.:
+
+  import:
+    profiles:
+      mybank:
+        account: Assets:Bank:MyBank
+        counter_account: Expenses:Food # optional, otherwise picked by rules
+        date_column: Date
+        description_column: Payee
+        amount_column: Amount        # or debit_column / credit_column
+        date_format: 02/01/2006      # Go layout, default 2006-01-02
+        decimal_separator: ","       # default "."
+        delimiter: ";"               # default ","
+
+Example: kea import csv statement.csv --profile mybank`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &csvRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Profile, "profile", "p", "", "Import profile name from the config file")
+	cmd.Flags().StringVarP(&flags.Account, "account", "a", "", "Override the profile's target account")
+	cmd.Flags().StringVarP(&flags.To, "to", "t", "", "Override the profile's counter account (default: picked by rules)")
+	_ = cmd.MarkFlagRequired("profile")
+
+	return cmd
+}
+
+func (r *csvRunner) Run(args []string) error {
+	profile, err := r.svc.Import.GetCSVProfile(r.flags.Profile)
+	if err != nil {
+		return err
+	}
+
+	if r.flags.Account != "" {
+		profile.Account = r.flags.Account
+	}
+	if r.flags.To != "" {
+		profile.CounterAccount = r.flags.To
+	}
+
+	account, err := r.svc.Account.GetAccountByName(profile.Account)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	records, err := importer.ParseCSV(file, profile, account.Currency)
+	if err != nil {
+		return fmt.Errorf("failed to parse csv: %w", err)
+	}
+
+	result, err := r.svc.Import.ImportRecords(profile.Account, profile.CounterAccount, records)
+	if err != nil {
+		return err
+	}
+
+	if err := views.RenderImportResult(result, len(records)); err != nil {
+		return err
+	}
+
+	return importFailure(result, len(records))
+}
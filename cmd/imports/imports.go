@@ -0,0 +1,33 @@
+package imports
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+func NewImportCmd(svc *service.Service) *cobra.Command {
+	importCmd := &cobra.Command{
+		Use:     "import",
+		Aliases: []string{"im"},
+		Short:   "Import transactions from bank statements",
+		Long: `Import transactions from bank statement files.
+Rows that were imported before are detected and skipped.`,
+	}
+
+	importCmd.AddCommand(NewCSVCmd(svc))
+	importCmd.AddCommand(NewOFXCmd(svc))
+
+	return importCmd
+}
+
+// importFailure fails the command when any row could not be imported, so
+// scripts see it in the exit status. The rows are listed by the result view.
+func importFailure(result *service.ImportResult, total int) error {
+	if len(result.Failures) == 0 {
+		return nil
+	}
+	return views.Reported(fmt.Errorf("%d of %d rows failed to import", len(result.Failures), total))
+}
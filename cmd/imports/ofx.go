@@ -0,0 +1,139 @@
+package imports
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/importer"
+	"github.com/hance08/kea/internal/ofx"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type ofxFlags struct {
+	Account string
+	To      string
+}
+
+type ofxRunner struct {
+	svc   *service.Service
+	flags *ofxFlags
+}
+
+func NewOFXCmd(svc *service.Service) *cobra.Command {
+	flags := &ofxFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "ofx <file>",
+		Aliases: []string{"qfx"},
+		Short:   "Import an OFX/QFX bank statement",
+		Long: `Import an OFX/QFX bank statement (SGML 1.x or XML 2.x).
+
+Each statement entry becomes a two-split transaction between --account and
+the counter account, which is picked by categorization rules unless --to is
+given. The bank's FITID is stored as external ID, so entries
+that were imported before are skipped. If the statement carries a ledger
+balance, it is compared with kea's balance at the statement date.
+
+Example: kea import ofx statement.ofx --account Assets:Bank:X`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &ofxRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Account, "account", "a", "", "Account the statement belongs to")
+	cmd.Flags().StringVarP(&flags.To, "to", "t", "", "Counter account for all entries (default: picked by rules)")
+	_ = cmd.MarkFlagRequired("account")
+
+	return cmd
+}
+
+func (r *ofxRunner) Run(args []string) error {
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	statements, err := ofx.Parse(file)
+	if err != nil {
+		return fmt.Errorf("failed to parse ofx: %w", err)
+	}
+	if len(statements) != 1 {
+		return fmt.Errorf("file contains %d statements, only single-statement files are supported", len(statements))
+	}
+	stmt := statements[0]
+
+	account, err := r.svc.Account.GetAccountByName(r.flags.Account)
+	if err != nil {
+		return err
+	}
+
+	if stmt.Currency != "" && stmt.Currency != account.Currency {
+		pterm.Warning.Printf("Statement currency %s differs from %s currency %s\n", stmt.Currency, account.Name, account.Currency)
+	}
+
+	records, err := importer.RecordsFromOFX(stmt, account.Currency)
+	if err != nil {
+		return fmt.Errorf("failed to parse ofx: %w", err)
+	}
+
+	result, err := r.svc.Import.ImportRecords(r.flags.Account, r.flags.To, records)
+	if err != nil {
+		return err
+	}
+
+	if err := views.RenderImportResult(result, len(records)); err != nil {
+		return err
+	}
+
+	if err := r.checkLedgerBalance(stmt, account.Currency); err != nil {
+		return err
+	}
+
+	return importFailure(result, len(records))
+}
+
+// checkLedgerBalance warns when kea's balance differs from the statement's LEDGERBAL.
+func (r *ofxRunner) checkLedgerBalance(stmt ofx.Statement, currency string) error {
+	if stmt.LedgerBalance == nil {
+		return nil
+	}
+
+	statement, err := commodity.Parse(stmt.LedgerBalance.Amount, currency)
+	if err != nil {
+		return fmt.Errorf("invalid ledger balance: %w", err)
+	}
+
+	asOf := stmt.LedgerBalance.AsOf
+	cutoff := asOf.AddDate(0, 0, 1).Unix() - 1
+
+	ledger, err := r.svc.Import.LedgerBalanceAsOf(r.flags.Account, cutoff)
+	if err != nil {
+		return err
+	}
+
+	date := asOf.Format("2006-01-02")
+	if ledger != statement {
+		pterm.Warning.Printf("Balance mismatch at %s: statement %s, ledger %s (difference %s)\n",
+			date,
+			commodity.Format(statement, currency),
+			commodity.Format(ledger, currency),
+			commodity.Format(statement-ledger, currency),
+		)
+		return nil
+	}
+
+	pterm.Success.Printf("Ledger balance matches statement balance at %s: %s\n", date, commodity.Format(ledger, currency))
+	return nil
+}
@@ -0,0 +1,89 @@
+package invest
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type buyFlags struct {
+	Price string
+	From  string
+	Date  string
+	Desc  string
+}
+
+type buyRunner struct {
+	svc   *service.Service
+	flags *buyFlags
+}
+
+func NewBuyCmd(svc *service.Service) *cobra.Command {
+	flags := &buyFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "buy <account> <quantity>",
+		Aliases: []string{"b"},
+		Short:   "Buy units into an investment account",
+		Long: `Buy units of the commodity held by an investment account, paid from a cash account.
+
+The price is per unit in the cash account's currency.
+
+Example: kea invest buy Assets:Brokerage:AAPL 10 --price 185.20 --from Assets:Brokerage:Cash`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &buyRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Price, "price", "", "Price per unit")
+	cmd.Flags().StringVarP(&flags.From, "from", "f", "", "Account the purchase is paid from")
+	cmd.Flags().StringVar(&flags.Date, "date", "", "Trade date (YYYY-MM-DD), default is today")
+	cmd.Flags().StringVarP(&flags.Desc, "desc", "d", "", "Transaction description")
+	_ = cmd.MarkFlagRequired("price")
+	_ = cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+func (r *buyRunner) Run(args []string) error {
+	account, err := r.svc.Account.GetAccountByName(args[0])
+	if err != nil {
+		return err
+	}
+
+	quantity, err := commodity.Parse(args[1], account.Currency)
+	if err != nil {
+		return fmt.Errorf("invalid quantity: %w", err)
+	}
+
+	date, err := parseTradeDate(r.flags.Date)
+	if err != nil {
+		return err
+	}
+
+	desc := r.flags.Desc
+	if desc == "" {
+		desc = fmt.Sprintf("Buy %s %s", args[1], account.Currency)
+	}
+
+	trade, err := r.svc.Investment.Buy(account.Name, r.flags.From, quantity, r.flags.Price, desc, date)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Bought %s for %s (ID: %d)\n",
+		commodity.FormatWithCode(trade.Quantity, trade.Commodity),
+		commodity.FormatWithCode(trade.Amount, trade.Currency),
+		trade.TransactionID)
+
+	return views.RenderTrade(trade)
+}
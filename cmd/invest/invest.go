@@ -0,0 +1,43 @@
+package invest
+
+import (
+	"time"
+
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewInvestCmd(svc *service.Service) *cobra.Command {
+	investCmd := &cobra.Command{
+		Use:     "invest",
+		Aliases: []string{"inv"},
+		Short:   "Buy and sell investments tracked by lot",
+		Long: `Buy and sell stocks, funds and other commodities held in investment accounts.
+
+An investment account is an asset account whose currency is the ticker, e.g.
+
+  kea commodity add AAPL --precision 4
+  kea account create -n AAPL -p Assets:Brokerage --currency AAPL
+
+Every buy opens a lot with its quantity and cost. Sales are matched against
+lots, oldest first unless specific lots are given, and the realized gain or
+loss is posted to a revenue account. Trade prices are saved to the price
+table so holdings can be valued.`,
+	}
+
+	investCmd.AddCommand(NewBuyCmd(svc))
+	investCmd.AddCommand(NewSellCmd(svc))
+	investCmd.AddCommand(NewLotsCmd(svc))
+
+	return investCmd
+}
+
+// parseTradeDate returns the start of the given date, or of today if empty.
+func parseTradeDate(dateStr string) (int64, error) {
+	if dateStr == "" {
+		dateStr = time.Now().Format(constants.DateFormat)
+	}
+	return utils.ParseDateStart(dateStr)
+}
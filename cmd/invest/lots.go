@@ -0,0 +1,35 @@
+package invest
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type lotsRunner struct {
+	svc *service.Service
+}
+
+func NewLotsCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:     "lots <account>",
+		Aliases: []string{"l"},
+		Short:   "List the open lots of an investment account",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &lotsRunner{svc: svc}
+			return runner.Run(args)
+		},
+	}
+}
+
+func (r *lotsRunner) Run(args []string) error {
+	holdings, err := r.svc.Investment.GetHolding(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get lots: %w", err)
+	}
+
+	return views.RenderLots(holdings)
+}
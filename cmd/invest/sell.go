@@ -0,0 +1,101 @@
+package invest
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type sellFlags struct {
+	Price       string
+	To          string
+	GainAccount string
+	Lots        []int64
+	Date        string
+	Desc        string
+}
+
+type sellRunner struct {
+	svc   *service.Service
+	flags *sellFlags
+}
+
+func NewSellCmd(svc *service.Service) *cobra.Command {
+	flags := &sellFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "sell <account> <quantity>",
+		Aliases: []string{"s"},
+		Short:   "Sell units from an investment account",
+		Long: `Sell units of the commodity held by an investment account, paid into a cash account.
+
+Units are taken from the oldest lots first (FIFO). Use --lot, possibly more
+than once, to sell from specific lots instead; see 'kea invest lots'. The
+difference between the proceeds and the cost of the sold units is posted
+to the gain account, Revenue:CapitalGains by default, or
+Revenue:CapitalGains:<currency> when the cash account is in another currency
+than the default.
+
+Examples:
+  kea invest sell Assets:Brokerage:AAPL 5 --price 201.10 --to Assets:Brokerage:Cash
+  kea invest sell Assets:Brokerage:AAPL 5 --price 201.10 --to Assets:Brokerage:Cash --lot 3`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &sellRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Price, "price", "", "Price per unit")
+	cmd.Flags().StringVarP(&flags.To, "to", "t", "", "Account the proceeds are paid into")
+	cmd.Flags().StringVar(&flags.GainAccount, "gain-account", "", "Revenue account for the realized gain or loss")
+	cmd.Flags().Int64SliceVar(&flags.Lots, "lot", nil, "Sell from this lot ID, in the order given")
+	cmd.Flags().StringVar(&flags.Date, "date", "", "Trade date (YYYY-MM-DD), default is today")
+	cmd.Flags().StringVarP(&flags.Desc, "desc", "d", "", "Transaction description")
+	_ = cmd.MarkFlagRequired("price")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func (r *sellRunner) Run(args []string) error {
+	account, err := r.svc.Account.GetAccountByName(args[0])
+	if err != nil {
+		return err
+	}
+
+	quantity, err := commodity.Parse(args[1], account.Currency)
+	if err != nil {
+		return fmt.Errorf("invalid quantity: %w", err)
+	}
+
+	date, err := parseTradeDate(r.flags.Date)
+	if err != nil {
+		return err
+	}
+
+	desc := r.flags.Desc
+	if desc == "" {
+		desc = fmt.Sprintf("Sell %s %s", args[1], account.Currency)
+	}
+
+	trade, err := r.svc.Investment.Sell(account.Name, r.flags.To, r.flags.GainAccount,
+		quantity, r.flags.Price, r.flags.Lots, desc, date)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Sold %s for %s (ID: %d)\n",
+		commodity.FormatWithCode(trade.Quantity, trade.Commodity),
+		commodity.FormatWithCode(trade.Amount, trade.Currency),
+		trade.TransactionID)
+
+	return views.RenderTrade(trade)
+}
@@ -0,0 +1,64 @@
+package price
+
+import (
+	"time"
+
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type addFlags struct {
+	Date string
+}
+
+type addRunner struct {
+	svc   *service.Service
+	flags *addFlags
+}
+
+func NewAddCmd(svc *service.Service) *cobra.Command {
+	flags := &addFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "add <base> <quote> <rate>",
+		Aliases: []string{"a"},
+		Short:   "Record a price",
+		Long: `Record that one unit of base is worth rate units of quote.
+
+Example: kea price add EUR USD 1.0873 --date 2025-03-01`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &addRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Date, "date", "", "Price date (YYYY-MM-DD), default is today")
+
+	return cmd
+}
+
+func (r *addRunner) Run(args []string) error {
+	dateStr := r.flags.Date
+	if dateStr == "" {
+		dateStr = time.Now().Format(constants.DateFormat)
+	}
+	date, err := utils.ParseDateStart(dateStr)
+	if err != nil {
+		return err
+	}
+
+	price, err := r.svc.Price.AddPrice(args[0], args[1], args[2], date)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Price saved: 1 %s = %s %s on %s\n", price.Base, price.Rate, price.Quote, dateStr)
+	return nil
+}
@@ -0,0 +1,57 @@
+package price
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hance08/kea/internal/importer"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type importRunner struct {
+	svc *service.Service
+}
+
+func NewImportCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import prices from a CSV file",
+		Long: `Import prices from a CSV file with the columns date,base,quote,rate.
+A header row is optional. Existing prices for the same day and pair are replaced.
+
+  date,base,quote,rate
+  2025-03-01,EUR,USD,1.0873
+  2025-03-01,USD,JPY,149.80
+
+Example: kea price import rates.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &importRunner{svc: svc}
+			return runner.Run(args)
+		},
+	}
+}
+
+func (r *importRunner) Run(args []string) error {
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	records, err := importer.ParsePriceCSV(file)
+	if err != nil {
+		return fmt.Errorf("failed to parse csv: %w", err)
+	}
+
+	result, err := r.svc.Price.ImportPrices(records)
+	if err != nil {
+		return err
+	}
+
+	return views.RenderImportResult(result, len(records))
+}
@@ -0,0 +1,40 @@
+package price
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type listRunner struct {
+	svc *service.Service
+}
+
+func NewListCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list [commodity]",
+		Aliases: []string{"ls", "l"},
+		Short:   "List prices, newest first",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &listRunner{svc: svc}
+			return runner.Run(args)
+		},
+	}
+}
+
+func (r *listRunner) Run(args []string) error {
+	commodity := ""
+	if len(args) == 1 {
+		commodity = args[0]
+	}
+
+	prices, err := r.svc.Price.ListPrices(commodity)
+	if err != nil {
+		return fmt.Errorf("failed to get prices: %w", err)
+	}
+
+	return views.NewPriceListView().Render(prices)
+}
@@ -0,0 +1,24 @@
+package price
+
+import (
+	"github.com/hance08/kea/internal/service"
+	"github.com/spf13/cobra"
+)
+
+func NewPriceCmd(svc *service.Service) *cobra.Command {
+	priceCmd := &cobra.Command{
+		Use:   "price",
+		Short: "Manage exchange rates and commodity prices",
+		Long: `Manage the price database used to convert balances into the default currency.
+
+A price states how many units of the quote commodity one unit of the base
+commodity is worth on a given day. Reports and 'kea account list' use the
+most recent price on or before the date they are run for, in either direction.`,
+	}
+
+	priceCmd.AddCommand(NewAddCmd(svc))
+	priceCmd.AddCommand(NewListCmd(svc))
+	priceCmd.AddCommand(NewImportCmd(svc))
+
+	return priceCmd
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/prompts"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+const (
+	reconcileOptionFinish = "Finish (mark selected as reconciled)"
+	reconcileOptionAll    = "Select all cleared"
+	reconcileOptionCancel = "Cancel"
+)
+
+type reconcileFlags struct {
+	StatementDate    string
+	StatementBalance string
+	AllCleared       bool
+}
+
+type reconcileRunner struct {
+	svc   *service.Service
+	flags *reconcileFlags
+}
+
+func NewReconcileCmd(svc *service.Service) *cobra.Command {
+	flags := &reconcileFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "reconcile <account>",
+		Short: "Reconcile an account against a bank statement",
+		Long: `Reconcile an account against a bank statement.
+
+Pending and cleared transactions up to the statement date are listed and can
+be ticked off one by one while the running difference to the statement
+balance is shown. When the difference reaches zero, the selected transactions
+are marked reconciled and can no longer be edited or deleted.
+
+The statement balance uses the account's natural sign, e.g. the amount owed
+for a credit card. With --all-cleared every cleared transaction is selected
+and the reconciliation finishes without prompting, e.g. in scripts.
+
+Example: kea reconcile Assets:Bank --statement-date 2025-03-31 --statement-balance 1520.30`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &reconcileRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.StatementDate, "statement-date", "", "Statement date (YYYY-MM-DD), default is today")
+	cmd.Flags().StringVar(&flags.StatementBalance, "statement-balance", "", "Statement ending balance")
+	cmd.Flags().BoolVar(&flags.AllCleared, "all-cleared", false, "Reconcile all cleared transactions without prompting")
+	_ = cmd.MarkFlagRequired("statement-balance")
+
+	return cmd
+}
+
+func (r *reconcileRunner) Run(args []string) error {
+	dateStr := r.flags.StatementDate
+	if dateStr == "" {
+		dateStr = time.Now().Format(constants.DateFormat)
+	}
+	statementDate, err := utils.ParseDateEnd(dateStr)
+	if err != nil {
+		return err
+	}
+
+	account, err := r.svc.Account.GetAccountByName(args[0])
+	if err != nil {
+		return err
+	}
+
+	balance, err := commodity.Parse(r.flags.StatementBalance, account.Currency)
+	if err != nil {
+		return fmt.Errorf("invalid statement balance: %w", err)
+	}
+
+	session, err := r.svc.Reconcile.StartSession(args[0], statementDate, balance)
+	if err != nil {
+		return err
+	}
+
+	if len(session.Entries) == 0 && session.Difference(nil) != 0 {
+		return fmt.Errorf("no unreconciled transactions found up to %s", dateStr)
+	}
+
+	selected := make(map[int64]bool)
+
+	if r.flags.AllCleared {
+		selectCleared(session, selected)
+		if err := r.svc.Reconcile.Complete(session, selected); err != nil {
+			return err
+		}
+		return views.RenderReconcileResult(session, selected)
+	}
+
+	for {
+		if err := views.RenderReconcileStatus(session, selected); err != nil {
+			return err
+		}
+
+		choice, err := r.promptEntry(session, selected)
+		if err != nil {
+			return err
+		}
+
+		switch choice {
+		case reconcileOptionCancel:
+			pterm.Info.Println("Reconciliation cancelled")
+			return nil
+
+		case reconcileOptionAll:
+			selectCleared(session, selected)
+
+		case reconcileOptionFinish:
+			if err := r.svc.Reconcile.Complete(session, selected); err != nil {
+				pterm.Error.Println(err)
+				continue
+			}
+			return views.RenderReconcileResult(session, selected)
+
+		default:
+			var splitID int64
+			if _, err := fmt.Sscanf(choice, "#%d", &splitID); err == nil {
+				selected[splitID] = !selected[splitID]
+			}
+		}
+	}
+}
+
+// selectCleared selects the entries of every cleared transaction.
+func selectCleared(session *service.ReconcileSession, selected map[int64]bool) {
+	for _, entry := range session.Entries {
+		if entry.Status != 0 {
+			selected[entry.SplitID] = true
+		}
+	}
+}
+
+func (r *reconcileRunner) promptEntry(session *service.ReconcileSession, selected map[int64]bool) (string, error) {
+	sign := service.NaturalSign(session.Account.Type)
+
+	options := []string{reconcileOptionFinish, reconcileOptionAll}
+	for _, entry := range session.Entries {
+		mark := "[ ]"
+		if selected[entry.SplitID] {
+			mark = "[x]"
+		}
+		options = append(options, fmt.Sprintf("#%d %s %s %s %s",
+			entry.SplitID, mark,
+			utils.FormatDate(entry.Timestamp),
+			commodity.Format(entry.Amount*sign, session.Account.Currency),
+			entry.Description,
+		))
+	}
+	options = append(options, reconcileOptionCancel)
+
+	return prompts.PromptSelect("Toggle a transaction or finish:", options, "")
+}
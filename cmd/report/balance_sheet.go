@@ -0,0 +1,58 @@
+package report
+
+import (
+	"fmt"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type balanceSheetFlags struct {
+	Date string
+}
+
+type balanceSheetRunner struct {
+	svc   *service.Service
+	flags *balanceSheetFlags
+}
+
+func NewBalanceSheetCmd(svc *service.Service) *cobra.Command {
+	flags := &balanceSheetFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "balance-sheet",
+		Aliases: []string{"bs"},
+		Short:   "Show the balance sheet as of a date",
+		Long: `Show Assets, Liabilities and Equity as of a cutoff date.
+
+Balances of sub-accounts are rolled up into their parents, and the report
+checks that A = L + C + (R - E) holds.
+
+Example: kea report balance-sheet --date 2025-12-31`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &balanceSheetRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run()
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Date, "date", "", "Cutoff date (YYYY-MM-DD), default is today")
+
+	return cmd
+}
+
+func (r *balanceSheetRunner) Run() error {
+	cutoff, err := parseEndOfDay(r.flags.Date)
+	if err != nil {
+		return err
+	}
+
+	sheet, err := r.svc.Report.GetBalanceSheet(cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to build balance sheet: %w", err)
+	}
+
+	return views.RenderBalanceSheet(sheet)
+}
@@ -0,0 +1,38 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type holdingsRunner struct {
+	svc *service.Service
+}
+
+func NewHoldingsCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:     "holdings",
+		Aliases: []string{"ho"},
+		Short:   "Show investment holdings with cost basis and market value",
+		Long: `Show the quantity and cost basis of every investment account with open lots,
+valued at the latest price in the price table.
+
+Example: kea report holdings`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &holdingsRunner{svc: svc}
+			return runner.Run()
+		},
+	}
+}
+
+func (r *holdingsRunner) Run() error {
+	holdings, err := r.svc.Investment.GetHoldings()
+	if err != nil {
+		return fmt.Errorf("failed to get holdings: %w", err)
+	}
+
+	return views.RenderHoldings(holdings)
+}
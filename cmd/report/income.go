@@ -0,0 +1,68 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type incomeFlags struct {
+	From string
+	To   string
+}
+
+type incomeRunner struct {
+	svc   *service.Service
+	flags *incomeFlags
+}
+
+func NewIncomeCmd(svc *service.Service) *cobra.Command {
+	flags := &incomeFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "income",
+		Aliases: []string{"pl"},
+		Short:   "Show the income statement (profit & loss) for a date range",
+		Long: `Show Revenue and Expenses within a date range grouped by account
+hierarchy, together with the resulting net income.
+
+Example: kea report income --from 2025-01-01 --to 2025-03-31`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &incomeRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run()
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.From, "from", "", "Start date (YYYY-MM-DD), default is the first day of this month")
+	cmd.Flags().StringVar(&flags.To, "to", "", "End date (YYYY-MM-DD), default is today")
+
+	return cmd
+}
+
+func (r *incomeRunner) Run() error {
+	now := time.Now()
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	startTime, err := parseStartOfDay(r.flags.From, firstOfMonth)
+	if err != nil {
+		return err
+	}
+
+	endTime, err := parseEndOfDay(r.flags.To)
+	if err != nil {
+		return err
+	}
+
+	statement, err := r.svc.Report.GetIncomeStatement(startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("failed to build income statement: %w", err)
+	}
+
+	return views.RenderIncomeStatement(statement)
+}
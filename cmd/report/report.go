@@ -0,0 +1,43 @@
+package report
+
+import (
+	"time"
+
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func NewReportCmd(svc *service.Service) *cobra.Command {
+	reportCmd := &cobra.Command{
+		Use:     "report",
+		Aliases: []string{"r"},
+		Short:   "Generate financial reports",
+		Long:    `Generate financial reports such as the balance sheet and income statement.`,
+	}
+
+	reportCmd.AddCommand(NewBalanceSheetCmd(svc))
+	reportCmd.AddCommand(NewIncomeCmd(svc))
+	reportCmd.AddCommand(NewTrendCmd(svc))
+	reportCmd.AddCommand(NewHoldingsCmd(svc))
+	reportCmd.AddCommand(NewRevaluationCmd(svc))
+
+	return reportCmd
+}
+
+// parseStartOfDay returns the first second of the given date, or of fallback if empty.
+func parseStartOfDay(dateStr string, fallback time.Time) (int64, error) {
+	if dateStr == "" {
+		dateStr = fallback.Format(constants.DateFormat)
+	}
+	return utils.ParseDateStart(dateStr)
+}
+
+// parseEndOfDay returns the last second of the given date (today if empty).
+func parseEndOfDay(dateStr string) (int64, error) {
+	if dateStr == "" {
+		dateStr = time.Now().Format(constants.DateFormat)
+	}
+	return utils.ParseDateEnd(dateStr)
+}
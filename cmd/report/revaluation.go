@@ -0,0 +1,97 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type revaluationFlags struct {
+	Date    string
+	Post    bool
+	Account string
+}
+
+type revaluationRunner struct {
+	svc   *service.Service
+	flags *revaluationFlags
+}
+
+func NewRevaluationCmd(svc *service.Service) *cobra.Command {
+	flags := &revaluationFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "revaluation",
+		Aliases: []string{"reval"},
+		Short:   "Show unrealized exchange gains and losses",
+		Long: `Show the unrealized exchange gain or loss of every asset and liability
+held in a currency other than the default.
+
+The book value is what the balance cost in the default currency at the
+rates it was booked at; the value uses the closing rate on the given date.
+With --post, an adjusting transaction books the difference against
+Revenue:FX or the account given with --account, so the next revaluation
+starts from the closing rate.
+
+Examples:
+  kea report revaluation --date 2025-12-31
+  kea report revaluation --date 2025-12-31 --post --account Equity:Revaluation`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &revaluationRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run()
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Date, "date", "", "Revaluation date (YYYY-MM-DD), default is today")
+	cmd.Flags().BoolVar(&flags.Post, "post", false, "Post an adjusting transaction for the gains")
+	cmd.Flags().StringVar(&flags.Account, "account", "", "Equity or revenue account for the adjustment, default is Revenue:FX")
+
+	return cmd
+}
+
+func (r *revaluationRunner) Run() error {
+	dateStr := r.flags.Date
+	if dateStr == "" {
+		dateStr = time.Now().Format(constants.DateFormat)
+	}
+
+	cutoff, err := utils.ParseDateEnd(dateStr)
+	if err != nil {
+		return err
+	}
+
+	rev, err := r.svc.Report.GetRevaluation(cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to build revaluation: %w", err)
+	}
+
+	if err := views.RenderRevaluation(rev); err != nil {
+		return err
+	}
+
+	if !r.flags.Post {
+		return nil
+	}
+
+	postDate, err := utils.ParseDateStart(dateStr)
+	if err != nil {
+		return err
+	}
+
+	txID, err := r.svc.Report.PostRevaluation(rev, r.flags.Account, postDate)
+	if err != nil {
+		return fmt.Errorf("failed to post revaluation: %w", err)
+	}
+
+	pterm.Success.Printf("Revaluation posted (ID: %d)\n", txID)
+	return nil
+}
@@ -0,0 +1,76 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type trendFlags struct {
+	Period string
+	From   string
+	To     string
+	Type   string
+}
+
+type trendRunner struct {
+	svc   *service.Service
+	flags *trendFlags
+}
+
+func NewTrendCmd(svc *service.Service) *cobra.Command {
+	flags := &trendFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Show per-account totals side by side for each period",
+		Long: `Show a matrix of account x period totals for one account type,
+with row totals, column totals and averages.
+
+Example: kea report trend --period month --from 2025-01-01 --to 2025-06-30 --type E`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &trendRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Period, "period", "p", service.PeriodMonth, "Period: month, quarter or year")
+	cmd.Flags().StringVar(&flags.From, "from", "", "Start date (YYYY-MM-DD), default is the first day of this year")
+	cmd.Flags().StringVar(&flags.To, "to", "", "End date (YYYY-MM-DD), default is today")
+	cmd.Flags().StringVarP(&flags.Type, "type", "t", "E", "Account type (A, L, C, R, E)")
+
+	return cmd
+}
+
+func (r *trendRunner) Run() error {
+	accType := strings.ToUpper(r.flags.Type)
+	rootName, err := r.svc.Account.GetRootNameByType(accType)
+	if err != nil {
+		return err
+	}
+
+	firstOfYear := time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	startTime, err := parseStartOfDay(r.flags.From, firstOfYear)
+	if err != nil {
+		return err
+	}
+
+	endTime, err := parseEndOfDay(r.flags.To)
+	if err != nil {
+		return err
+	}
+
+	report, err := r.svc.Report.GetTrend(accType, strings.ToLower(r.flags.Period), startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("failed to build trend report: %w", err)
+	}
+
+	return views.NewTrendReportView().Render(report, rootName)
+}
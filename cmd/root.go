@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -10,20 +11,31 @@ import (
 	"unicode"
 
 	"github.com/hance08/kea/cmd/account"
+	"github.com/hance08/kea/cmd/commodity"
+	"github.com/hance08/kea/cmd/export"
+	"github.com/hance08/kea/cmd/imports"
+	"github.com/hance08/kea/cmd/invest"
+	"github.com/hance08/kea/cmd/price"
+	"github.com/hance08/kea/cmd/report"
+	"github.com/hance08/kea/cmd/rule"
+	"github.com/hance08/kea/cmd/schedule"
 	"github.com/hance08/kea/cmd/transaction"
 	"github.com/hance08/kea/internal/app"
 	"github.com/hance08/kea/internal/config"
 	"github.com/hance08/kea/internal/constants"
 	"github.com/hance08/kea/internal/service"
 	"github.com/hance08/kea/internal/ui/prompts"
+	"github.com/hance08/kea/internal/ui/views"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
 var (
-	cfgFile string
-	cfg     *config.Config
+	cfgFile      string
+	outputFormat string
+	cfg          *config.Config
 )
 
 func Execute(migrations fs.FS) {
@@ -32,22 +44,28 @@ func Execute(migrations fs.FS) {
 		Style: pterm.NewStyle(pterm.BgLightRed, pterm.FgBlack),
 	}
 
+	// --output is read ahead of cobra so that errors while starting up are
+	// already reported in the requested format
+	if err := views.SetOutputFormat(peekOutputFormat(os.Args[1:])); err != nil {
+		exitWithError(err)
+	}
+	if views.Structured() {
+		prompts.SetInteractive(false)
+	}
+
 	if err := initConfig(); err != nil {
-		pterm.Error.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
 	application, cleanup, err := app.NewApp(cfg, migrations)
 	if err != nil {
-		pterm.Error.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
 	defer cleanup()
 
 	if err := initSysAcc(application.Service); err != nil {
-		pterm.Error.Println(err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
 	rootCmd := &cobra.Command{
@@ -58,22 +76,51 @@ func Execute(migrations fs.FS) {
 	}
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "set the config file path")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", views.OutputTable, "output format: table, json, csv or yaml")
 
 	rootCmd.AddCommand(account.NewAccountCmd(application.Service))
 	rootCmd.AddCommand(transaction.NewTransactionCmd(application.Service))
+	rootCmd.AddCommand(imports.NewImportCmd(application.Service))
+	rootCmd.AddCommand(rule.NewRuleCmd(application.Service))
+	rootCmd.AddCommand(export.NewExportCmd(application.Service))
+	rootCmd.AddCommand(price.NewPriceCmd(application.Service))
+	rootCmd.AddCommand(commodity.NewCommodityCmd(application.Service))
+	rootCmd.AddCommand(invest.NewInvestCmd(application.Service))
+	rootCmd.AddCommand(schedule.NewScheduleCmd(application.Service))
 
 	rootCmd.AddCommand(NewAddCmd(application.Service))
 	rootCmd.AddCommand(NewInfoCmd(application.Service))
-	rootCmd.AddCommand(NewReportCmd())
+	rootCmd.AddCommand(NewReconcileCmd(application.Service))
+	rootCmd.AddCommand(report.NewReportCmd(application.Service))
+	rootCmd.AddCommand(NewServeCmd(application.Service))
+	rootCmd.AddCommand(NewTUICmd(application.Service))
 
 	rootCmd.SilenceErrors = true
 	if err := rootCmd.Execute(); err != nil {
-		errMsg := err.Error()
-		displayMsg := capitalize(errMsg)
+		exitWithError(err)
+	}
+}
 
-		pterm.Error.Println(displayMsg)
-		os.Exit(1)
+// exitWithError reports err in the selected output format and exits. Errors
+// a view has already shown in the structured output only go to stderr.
+func exitWithError(err error) {
+	if views.IsReported(err) {
+		pterm.Error.Println(capitalize(err.Error()))
+	} else {
+		views.RenderError(capitalize(err.Error()))
 	}
+	os.Exit(1)
+}
+
+// peekOutputFormat returns the value of --output in args, ignoring all other
+// flags, or the table format when it is not given.
+func peekOutputFormat(args []string) string {
+	flags := pflag.NewFlagSet("kea", pflag.ContinueOnError)
+	flags.ParseErrorsAllowlist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	format := flags.StringP("output", "o", views.OutputTable, "")
+	_ = flags.Parse(args)
+	return *format
 }
 
 func initSysAcc(svc *service.Service) error {
@@ -0,0 +1,98 @@
+package rule
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type addFlags struct {
+	Pattern  string
+	Min      string
+	Max      string
+	Source   string
+	To       string
+	Priority int
+}
+
+type addRunner struct {
+	svc   *service.Service
+	flags *addFlags
+}
+
+func NewAddCmd(svc *service.Service) *cobra.Command {
+	flags := &addFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a categorization rule",
+		Long: `Add a rule that maps matching transactions to an Expenses or Revenue account.
+Description patterns are case-insensitive regular expressions; amounts are
+compared by absolute value.
+
+Examples:
+  kea rule add --pattern "starbucks|coffee" --to Expenses:Food:Coffee
+  kea rule add --pattern "^salary" --source Assets:Bank --to Revenue:Salary --priority 10
+  kea rule add --min 1000 --source Liabilities:CreditCard --to Expenses:Shopping`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &addRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Pattern, "pattern", "p", "", "Regular expression matched against the description")
+	cmd.Flags().StringVar(&flags.Min, "min", "", "Minimum amount (inclusive)")
+	cmd.Flags().StringVar(&flags.Max, "max", "", "Maximum amount (inclusive)")
+	cmd.Flags().StringVarP(&flags.Source, "source", "s", "", "Only match transactions of this account")
+	cmd.Flags().StringVarP(&flags.To, "to", "t", "", "Counter account to use when the rule matches")
+	cmd.Flags().IntVar(&flags.Priority, "priority", 0, "Higher priority rules are evaluated first")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func (r *addRunner) Run() error {
+	input := service.RuleInput{
+		Priority:           r.flags.Priority,
+		DescriptionPattern: r.flags.Pattern,
+		SourceAccount:      r.flags.Source,
+		TargetAccount:      r.flags.To,
+	}
+
+	currency, err := r.svc.Rule.AmountCurrency(r.flags.Source)
+	if err != nil {
+		return err
+	}
+
+	if input.MinAmount, err = parseOptionalAmount(r.flags.Min, currency); err != nil {
+		return fmt.Errorf("invalid --min: %w", err)
+	}
+	if input.MaxAmount, err = parseOptionalAmount(r.flags.Max, currency); err != nil {
+		return fmt.Errorf("invalid --max: %w", err)
+	}
+
+	ruleID, err := r.svc.Rule.AddRule(input)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Rule #%d created\n", ruleID)
+	return nil
+}
+
+func parseOptionalAmount(s, currency string) (*int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	amount, err := commodity.Parse(s, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &amount, nil
+}
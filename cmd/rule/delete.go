@@ -0,0 +1,39 @@
+package rule
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type deleteRunner struct {
+	svc *service.Service
+}
+
+func NewDeleteCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <rule-id>",
+		Short: "Delete a categorization rule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &deleteRunner{svc: svc}
+			return runner.Run(args)
+		},
+	}
+}
+
+func (r *deleteRunner) Run(args []string) error {
+	var ruleID int64
+	if _, err := fmt.Sscanf(args[0], "%d", &ruleID); err != nil {
+		return fmt.Errorf("invalid rule ID: %s", args[0])
+	}
+
+	if err := r.svc.Rule.DeleteRule(ruleID); err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Rule #%d deleted\n", ruleID)
+	return nil
+}
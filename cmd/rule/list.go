@@ -0,0 +1,34 @@
+package rule
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type listRunner struct {
+	svc *service.Service
+}
+
+func NewListCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "l"},
+		Short:   "List categorization rules in evaluation order",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &listRunner{svc: svc}
+			return runner.Run()
+		},
+	}
+}
+
+func (r *listRunner) Run() error {
+	rules, err := r.svc.Rule.ListRules()
+	if err != nil {
+		return fmt.Errorf("failed to get rules: %w", err)
+	}
+
+	return views.NewRuleListView().Render(rules)
+}
@@ -0,0 +1,27 @@
+package rule
+
+import (
+	"github.com/hance08/kea/internal/service"
+	"github.com/spf13/cobra"
+)
+
+func NewRuleCmd(svc *service.Service) *cobra.Command {
+	ruleCmd := &cobra.Command{
+		Use:   "rule",
+		Short: "Manage categorization rules",
+		Long: `Manage rules that pick the counter account for imported transactions
+and for 'kea add' when --to is omitted.
+
+Rules are evaluated by priority (highest first). The first rule whose
+conditions all match wins; if none matches, Expenses:Uncategorized is used,
+or Expenses:Uncategorized:<currency> for accounts in another currency than
+the default.`,
+	}
+
+	ruleCmd.AddCommand(NewAddCmd(svc))
+	ruleCmd.AddCommand(NewListCmd(svc))
+	ruleCmd.AddCommand(NewTestCmd(svc))
+	ruleCmd.AddCommand(NewDeleteCmd(svc))
+
+	return ruleCmd
+}
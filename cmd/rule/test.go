@@ -0,0 +1,72 @@
+package rule
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type testFlags struct {
+	Desc   string
+	Amount string
+	From   string
+}
+
+type testRunner struct {
+	svc   *service.Service
+	flags *testFlags
+}
+
+func NewTestCmd(svc *service.Service) *cobra.Command {
+	flags := &testFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Show which account the rules pick for a transaction",
+		Long: `Show which account the rules would pick for a transaction, without saving anything.
+
+Example: kea rule test --desc "STARBUCKS #123" --amount 5.40 --from Assets:Cash`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &testRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Desc, "desc", "d", "", "Transaction description")
+	cmd.Flags().StringVarP(&flags.Amount, "amount", "a", "0", "Transaction amount")
+	cmd.Flags().StringVarP(&flags.From, "from", "f", "", "Source account")
+
+	return cmd
+}
+
+func (r *testRunner) Run() error {
+	currency, err := r.svc.Rule.AmountCurrency(r.flags.From)
+	if err != nil {
+		return err
+	}
+
+	amount, err := commodity.Parse(r.flags.Amount, currency)
+	if err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+
+	matcher, err := r.svc.Rule.NewMatcher()
+	if err != nil {
+		return err
+	}
+
+	rule := matcher.Match(r.flags.Desc, amount, r.flags.From)
+	if rule == nil {
+		pterm.Warning.Println("No rule matched, transaction would go to Expenses:Uncategorized")
+		return nil
+	}
+
+	pterm.Success.Printf("Rule #%d matched: %s\n", rule.ID, rule.TargetAccount)
+	return nil
+}
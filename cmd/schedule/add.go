@@ -0,0 +1,142 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type addFlags struct {
+	Desc     string
+	Amount   string
+	From     string
+	To       string
+	Every    string
+	Interval int
+	Day      int
+	Start    string
+	End      string
+	Pending  bool
+}
+
+type addRunner struct {
+	svc   *service.Service
+	flags *addFlags
+}
+
+func NewAddCmd(svc *service.Service) *cobra.Command {
+	flags := &addFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a recurring transaction",
+		Long: `Add a transaction that repeats every day, week, month or year, starting
+on --start. Monthly and yearly schedules post on the day of the start date,
+or on --day; days past the end of a short month fall on its last day.
+
+Occurrences are posted as cleared, or as pending with --pending so that
+they can be checked before clearing them.
+
+Examples:
+  kea schedule add --desc "Rent" --amount 1200 --from Assets:Bank --to Expenses:Rent --every monthly --day 1
+  kea schedule add --desc "Salary" --amount 3000 --from Revenue:Salary --to Assets:Bank --every monthly --day 31
+  kea schedule add --desc "Netflix" --amount 15.99 --from Liabilities:CreditCard --to Expenses:Subscriptions \
+    --every monthly --start 2025-01-15 --pending
+  kea schedule add --desc "Gym" --amount 20 --from Assets:Cash --to Expenses:Sport --every weekly --interval 2 --end 2025-12-31`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &addRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Desc, "desc", "d", "", "Transaction description")
+	cmd.Flags().StringVarP(&flags.Amount, "amount", "a", "", "Amount of each occurrence, in the source account's currency")
+	cmd.Flags().StringVarP(&flags.From, "from", "f", "", "Source account (where money comes from)")
+	cmd.Flags().StringVarP(&flags.To, "to", "t", "", "Destination account (where money goes to)")
+	cmd.Flags().StringVarP(&flags.Every, "every", "e", "monthly", "Frequency: daily, weekly, monthly or yearly")
+	cmd.Flags().IntVar(&flags.Interval, "interval", 1, "Repeat every n days, weeks, months or years")
+	cmd.Flags().IntVar(&flags.Day, "day", 0, "Day of month for monthly and yearly schedules, default is the day of --start")
+	cmd.Flags().StringVar(&flags.Start, "start", "", "First date the transaction can occur (YYYY-MM-DD), default is today")
+	cmd.Flags().StringVar(&flags.End, "end", "", "Last date the transaction can occur (YYYY-MM-DD), default is never")
+	cmd.Flags().BoolVar(&flags.Pending, "pending", false, "Create occurrences as pending instead of posting them as cleared")
+	_ = cmd.MarkFlagRequired("desc")
+	_ = cmd.MarkFlagRequired("amount")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func (r *addRunner) Run() error {
+	if r.flags.From == r.flags.To {
+		return fmt.Errorf("source and destination accounts cannot be the same")
+	}
+
+	from, err := r.svc.Account.GetAccountByName(r.flags.From)
+	if err != nil {
+		return err
+	}
+	to, err := r.svc.Account.GetAccountByName(r.flags.To)
+	if err != nil {
+		return err
+	}
+	if from.Currency != to.Currency {
+		return fmt.Errorf("scheduled transfers need both accounts in one currency, %s uses %s and %s uses %s",
+			from.Name, from.Currency, to.Name, to.Currency)
+	}
+
+	amount, err := commodity.Parse(r.flags.Amount, from.Currency)
+	if err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+	if amount <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+
+	startDate := r.flags.Start
+	if startDate == "" {
+		startDate = time.Now().Format(constants.DateFormat)
+	}
+	start, err := utils.ParseDateStart(startDate)
+	if err != nil {
+		return fmt.Errorf("invalid --start: %w", err)
+	}
+
+	var end int64
+	if r.flags.End != "" {
+		if end, err = utils.ParseDateStart(r.flags.End); err != nil {
+			return fmt.Errorf("invalid --end: %w", err)
+		}
+	}
+
+	scheduleID, err := r.svc.Schedule.AddSchedule(service.ScheduleInput{
+		Transaction: service.TransactionInput{
+			Description: r.flags.Desc,
+			Splits: []service.TransactionSplitInput{
+				{AccountName: to.Name, Amount: amount},
+				{AccountName: from.Name, Amount: -amount},
+			},
+		},
+		Frequency:  r.flags.Every,
+		Interval:   r.flags.Interval,
+		DayOfMonth: r.flags.Day,
+		StartDate:  start,
+		EndDate:    end,
+		AutoPost:   !r.flags.Pending,
+	})
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Scheduled transaction #%d created, post it with 'kea schedule run'\n", scheduleID)
+	return nil
+}
@@ -0,0 +1,41 @@
+package schedule
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type deleteRunner struct {
+	svc *service.Service
+}
+
+func NewDeleteCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <schedule-id>",
+		Short: "Delete a recurring transaction",
+		Long: `Delete a recurring transaction so that it is no longer posted.
+Transactions it has already posted are kept.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &deleteRunner{svc: svc}
+			return runner.Run(args)
+		},
+	}
+}
+
+func (r *deleteRunner) Run(args []string) error {
+	var scheduleID int64
+	if _, err := fmt.Sscanf(args[0], "%d", &scheduleID); err != nil {
+		return fmt.Errorf("invalid schedule ID: %s", args[0])
+	}
+
+	if err := r.svc.Schedule.DeleteSchedule(scheduleID); err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Scheduled transaction #%d deleted\n", scheduleID)
+	return nil
+}
@@ -0,0 +1,34 @@
+package schedule
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type listRunner struct {
+	svc *service.Service
+}
+
+func NewListCmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "l"},
+		Short:   "List recurring transactions and when they are next due",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &listRunner{svc: svc}
+			return runner.Run()
+		},
+	}
+}
+
+func (r *listRunner) Run() error {
+	schedules, err := r.svc.Schedule.ListSchedules()
+	if err != nil {
+		return fmt.Errorf("failed to get scheduled transactions: %w", err)
+	}
+
+	return views.RenderScheduleList(schedules)
+}
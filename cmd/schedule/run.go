@@ -0,0 +1,66 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type runFlags struct {
+	Until string
+}
+
+type runRunner struct {
+	svc   *service.Service
+	flags *runFlags
+}
+
+func NewRunCmd(svc *service.Service) *cobra.Command {
+	flags := &runFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Post the recurring transactions that are due",
+		Long: `Post every occurrence of the recurring transactions dated on or before
+--until that has not been posted yet. Occurrences missed since the last run
+are caught up, and running the command again posts nothing twice.
+
+Examples:
+  kea schedule run
+  kea schedule run --until 2025-12-31`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &runRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run()
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Until, "until", "", "Post occurrences up to this date (YYYY-MM-DD), default is today")
+
+	return cmd
+}
+
+func (r *runRunner) Run() error {
+	untilDate := r.flags.Until
+	if untilDate == "" {
+		untilDate = time.Now().Format(constants.DateFormat)
+	}
+	until, err := utils.ParseDateEnd(untilDate)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	postings, err := r.svc.Schedule.RunSchedules(until)
+	if err != nil {
+		return err
+	}
+
+	return views.RenderScheduleRun(postings, until)
+}
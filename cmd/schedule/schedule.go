@@ -0,0 +1,25 @@
+package schedule
+
+import (
+	"github.com/hance08/kea/internal/service"
+	"github.com/spf13/cobra"
+)
+
+func NewScheduleCmd(svc *service.Service) *cobra.Command {
+	scheduleCmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage recurring transactions",
+		Long: `Manage transactions that repeat, such as rent, salary or subscriptions.
+
+A schedule only records the recurrence; 'kea schedule run' posts every
+occurrence that is due. Each occurrence is posted exactly once, so the
+command can be run as often as you like, e.g. from cron.`,
+	}
+
+	scheduleCmd.AddCommand(NewAddCmd(svc))
+	scheduleCmd.AddCommand(NewListCmd(svc))
+	scheduleCmd.AddCommand(NewRunCmd(svc))
+	scheduleCmd.AddCommand(NewDeleteCmd(svc))
+
+	return scheduleCmd
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/hance08/kea/internal/server"
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+type serveFlags struct {
+	Addr string
+}
+
+type serveRunner struct {
+	svc   *service.Service
+	flags *serveFlags
+}
+
+func NewServeCmd(svc *service.Service) *cobra.Command {
+	flags := &serveFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the ledger as a JSON API over HTTP",
+		Long: `Serve accounts, transactions, balances and reports as a JSON API, for
+dashboards and scripts on other devices.
+
+Every request must send the token set as server.token in the config file:
+
+  Authorization: Bearer <token>
+
+Endpoints:
+  GET    /api/accounts                    ?type=A&archived=true
+  POST   /api/accounts                    {"name", "type" or "parent", "currency", "description", "balance"}
+  GET    /api/accounts/{name}
+  PATCH  /api/accounts/{name}             {"name"} to rename, {"parent"} to move, {"archived", "force"}
+  DELETE /api/accounts/{name}             ?reassign_to=Other:Account to merge
+  GET    /api/transactions                the filters of 'kea tx list', e.g. ?from=2025-01-01&account=Expenses:*&limit=20
+  POST   /api/transactions                {"date", "description", "status", "splits": [{"account", "amount", "memo"}]}
+  GET    /api/transactions/{id}
+  PUT    /api/transactions/{id}           the same body as POST, splits may keep their "id"
+  DELETE /api/transactions/{id}
+  GET    /api/balances
+  GET    /api/reports/balance-sheet       ?date=
+  GET    /api/reports/income              ?from=&to=
+  GET    /api/reports/trend               ?type=E&period=month&from=&to=
+
+Amounts are decimal strings in the currency of their account, e.g. "12.50".
+
+Example: kea serve --addr 127.0.0.1:8080`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &serveRunner{
+				svc:   svc,
+				flags: flags,
+			}
+			return runner.Run()
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Addr, "addr", "127.0.0.1:8080", "Address to listen on")
+
+	return cmd
+}
+
+func (r *serveRunner) Run() error {
+	token := r.svc.Config.Server.Token
+	if token == "" {
+		return fmt.Errorf("set server.token in %s so API clients can authenticate", r.svc.Config.ConfigPath)
+	}
+
+	srv := &http.Server{
+		Addr:              r.flags.Addr,
+		Handler:           server.New(r.svc, token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	pterm.Info.Printf("Serving the kea API on http://%s, press Ctrl+C to stop\n", r.flags.Addr)
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	// Let requests in flight finish so no write is cut off
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to stop server: %w", err)
+	}
+
+	pterm.Info.Println("Server stopped")
+	return nil
+}
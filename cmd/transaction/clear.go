@@ -34,8 +34,7 @@ func (r *clearRunner) Run(args []string) error {
 
 	// Update status to cleared (1)
 	if err := r.svc.Transaction.UpdateTransactionStatus(txID, 1); err != nil {
-		pterm.Error.Printf("Failed to update transaction status: %v\n", err)
-		return nil
+		return fmt.Errorf("failed to update transaction status: %w", err)
 	}
 
 	pterm.Success.Printf("Transaction #%d marked as cleared\n", txID)
@@ -36,8 +36,7 @@ func (r *deleteRunner) Run(args []string) error {
 	// Get transaction details first to show what will be deleted
 	detail, err := r.svc.Transaction.GetTransactionByID(txID)
 	if err != nil {
-		pterm.Error.Printf("Failed to delete transaction: %v\n", err)
-		return nil
+		return fmt.Errorf("failed to delete transaction: %w", err)
 	}
 
 	if err := views.RenderTransactionDeletePreview(views.TransactionDeletePreviewItem{
@@ -61,8 +60,7 @@ func (r *deleteRunner) Run(args []string) error {
 
 	// Delete transaction
 	if err := r.svc.Transaction.DeleteTransaction(txID); err != nil {
-		pterm.Error.Printf("Failed to delete transaction: %v\n", err)
-		return nil
+		return fmt.Errorf("failed to delete transaction: %w", err)
 	}
 
 	views.RenderTransactionDeleteSuccess(txID)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hance08/kea/internal/commodity"
 	"github.com/hance08/kea/internal/constants"
 	"github.com/hance08/kea/internal/model"
 	"github.com/hance08/kea/internal/service"
@@ -48,7 +49,7 @@ func (r *editRunner) Run(args []string) error {
 	}
 
 	if !r.svc.Transaction.IsEditable(detail) {
-		pterm.Error.Println("This transaction cannot be edited (System Transaction)")
+		pterm.Error.Println("This transaction cannot be edited (System, Reconciled or Investment Transaction)")
 		return nil
 	}
 
@@ -225,6 +226,7 @@ func (r *editRunner) actionQuickChangeAccount(detail *service.TransactionDetail)
 	// Apply Change
 	targetSplit.AccountID = newAcc.ID
 	targetSplit.AccountName = newAcc.Name
+	targetSplit.AccountType = newAcc.Type
 	targetSplit.Currency = newAcc.Currency
 
 	pterm.Success.Printf("Account changed to: %s\n", newAcc.Name)
@@ -241,12 +243,7 @@ func (r *editRunner) actionQuickChangeAmount(detail *service.TransactionDetail)
 	currentAbsAmount := utils.AbsInt64(detail.Splits[0].Amount)
 
 	// UI: Prompt
-	newAmountStr, err := prompts.PromptInput("Enter new amount:", utils.FormatFromCents(currentAbsAmount), nil)
-	if err != nil {
-		return err
-	}
-
-	newAmount, err := utils.ParseToCents(newAmountStr)
+	newAmount, err := r.promptAmount("Enter new amount:", currentAbsAmount, detail.Splits[0].Currency)
 	if err != nil {
 		return err
 	}
@@ -308,7 +305,7 @@ func (r *editRunner) actionAddSplit(detail *service.TransactionDetail) error {
 	acc, _ := r.svc.Account.GetAccountByName(accName)
 
 	// 2. Input Amount
-	amount, err := r.promptAmount("Amount (negative for credit):", 0)
+	amount, err := r.promptAmount("Amount (negative for credit):", 0, acc.Currency)
 	if err != nil {
 		return err
 	}
@@ -321,7 +318,7 @@ func (r *editRunner) actionAddSplit(detail *service.TransactionDetail) error {
 
 	// 4. Append
 	detail.Splits = append(detail.Splits, service.SplitDetail{
-		AccountID: acc.ID, AccountName: acc.Name, Currency: acc.Currency,
+		AccountID: acc.ID, AccountName: acc.Name, AccountType: acc.Type, Currency: acc.Currency,
 		Amount: amount, Memo: memo,
 	})
 	return nil
@@ -343,7 +340,7 @@ func (r *editRunner) actionEditSplit(detail *service.TransactionDetail) error {
 	acc, _ := r.svc.Account.GetAccountByName(newAccName)
 
 	// Edit Amount
-	newAmount, err := r.promptAmount("Amount:", split.Amount)
+	newAmount, err := r.promptAmount("Amount:", split.Amount, acc.Currency)
 	if err != nil {
 		return err
 	}
@@ -357,6 +354,7 @@ func (r *editRunner) actionEditSplit(detail *service.TransactionDetail) error {
 	// Apply
 	split.AccountID = acc.ID
 	split.AccountName = acc.Name
+	split.AccountType = acc.Type
 	split.Currency = acc.Currency
 	split.Amount = newAmount
 	split.Memo = newMemo
@@ -436,27 +434,37 @@ func (r *editRunner) promptAccountSelection(defaultName string) (string, error)
 func (r *editRunner) promptAccountSelectionFromList(accounts []*model.Account, defaultName string) (string, error) {
 	var names []string
 	for _, a := range accounts {
+		// Archived accounts stay selectable only when the split already uses them
+		if a.IsHidden && a.Name != defaultName {
+			continue
+		}
 		names = append(names, a.Name)
 	}
 	return prompts.PromptSelect("Select Account:", names, defaultName)
 }
 
-func (r *editRunner) promptAmount(label string, defaultCents int64) (int64, error) {
+func (r *editRunner) promptAmount(label string, defaultAmount int64, currency string) (int64, error) {
 	defaultStr := ""
-	if defaultCents != 0 {
-		defaultStr = utils.FormatFromCents(defaultCents)
+	if defaultAmount != 0 {
+		defaultStr = commodity.Format(defaultAmount, currency)
 	}
-	valStr, err := prompts.PromptInput(label, defaultStr, nil)
+
+	validator := func(s string) error {
+		_, err := commodity.Parse(s, currency)
+		return err
+	}
+
+	valStr, err := prompts.PromptInput(label, defaultStr, validator)
 	if err != nil {
 		return 0, err
 	}
-	return utils.ParseToCents(valStr)
+	return commodity.Parse(valStr, currency)
 }
 
 func (r *editRunner) promptSplitSelection(detail *service.TransactionDetail) (int, error) {
 	var options []string
 	for i, s := range detail.Splits {
-		options = append(options, fmt.Sprintf("#%d %s (%s)", i+1, s.AccountName, utils.FormatFromCents(s.Amount)))
+		options = append(options, fmt.Sprintf("#%d %s (%s)", i+1, s.AccountName, commodity.FormatWithCode(s.Amount, s.Currency)))
 	}
 	options = append(options, "Cancel")
 
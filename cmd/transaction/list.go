@@ -2,18 +2,33 @@ package transaction
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/hance08/kea/internal/commodity"
 	"github.com/hance08/kea/internal/model"
 	"github.com/hance08/kea/internal/service"
 	"github.com/hance08/kea/internal/ui/views"
+	"github.com/hance08/kea/internal/utils"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
 type listFlags struct {
-	Account string
-	Limit   int
+	Account   string
+	Limit     int
+	Offset    int
+	From      string
+	To        string
+	MinAmount string
+	MaxAmount string
+	Search    string
+	Regex     string
+	Status    []string
+	Type      string
+	Currency  string
+	Sort      string
+	Asc       bool
 }
 
 type listRunner struct {
@@ -27,11 +42,21 @@ func NewListCmd(svc *service.Service) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "list",
 		Aliases: []string{"ls", "l"},
-		Short:   "List recent transactions (alias: tls)",
+		Short:   "List and search transactions (alias: tls)",
 		Long: `List recent transactions from your accounting records.
 
 This command displays a table of transactions with their details including
-date, type, account, description, amount, and status.`,
+date, type, account, description, amount, and status.
+
+Filters can be combined, a transaction has to match all of them. Amounts are
+compared with the largest split of a transaction in the --currency, or in
+the default currency. Add ":*" to an account name to include its sub-accounts.
+
+Examples:
+  kea tx list --account "Expenses:Food:*" --from 2025-01-01 --to 2025-03-31
+  kea tx list --search coffee --min-amount 5 --sort amount
+  kea tx list --regex "^(Netflix|Spotify)" --type expense --status pending,cleared
+  kea tx list --limit 20 --offset 20`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runner := &listRunner{
 				svc:   svc,
@@ -41,39 +66,49 @@ date, type, account, description, amount, and status.`,
 		},
 	}
 
-	cmd.Flags().StringVarP(&flags.Account, "account", "a", "", "Filter transactions by account name")
-	cmd.Flags().IntVarP(&flags.Limit, "limit", "l", 20, "Maximum number of transactions to display")
+	cmd.Flags().StringVarP(&flags.Account, "account", "a", "", "Filter by account, end with :* to include sub-accounts")
+	cmd.Flags().IntVarP(&flags.Limit, "limit", "l", 20, "Maximum number of transactions to display, 0 for all")
+	cmd.Flags().IntVar(&flags.Offset, "offset", 0, "Number of transactions to skip, for paging")
+	cmd.Flags().StringVar(&flags.From, "from", "", "Start date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.To, "to", "", "End date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flags.MinAmount, "min-amount", "", "Minimum amount")
+	cmd.Flags().StringVar(&flags.MaxAmount, "max-amount", "", "Maximum amount")
+	cmd.Flags().StringVarP(&flags.Search, "search", "s", "", "Text in the description or a memo")
+	cmd.Flags().StringVar(&flags.Regex, "regex", "", "Regular expression matched against the description or a memo")
+	cmd.Flags().StringSliceVar(&flags.Status, "status", nil, "Statuses to show: pending, cleared, reconciled")
+	cmd.Flags().StringVarP(&flags.Type, "type", "t", "", "Transaction type: expense, income, transfer, opening, deposit, withdrawal, other")
+	cmd.Flags().StringVar(&flags.Currency, "currency", "", "Only transactions with a split in this currency")
+	cmd.Flags().StringVar(&flags.Sort, "sort", "", "Sort by date, amount or description (default date, newest first)")
+	cmd.Flags().BoolVar(&flags.Asc, "asc", false, "Sort in ascending order")
 
 	return cmd
 }
 
 func (r *listRunner) Run() error {
-	var transactions []*model.Transaction
-	var err error
+	filter, err := r.buildFilter()
+	if err != nil {
+		return err
+	}
+
+	page, err := r.svc.Transaction.SearchTransactions(filter)
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	transactions := page.Transactions
 
 	if r.flags.Account != "" {
-		// List transactions for specific account
-		transactions, err = r.svc.Transaction.GetTransactionHistory(r.flags.Account, r.flags.Limit)
-		if err != nil {
-			return fmt.Errorf("failed to get transactions: %w", err)
-		}
 		pterm.Info.Printf("Showing transactions for account: %s\n\n", r.flags.Account)
-	} else {
-		// List all recent transactions
-		transactions, err = r.svc.Transaction.GetRecentTransactions(r.flags.Limit)
-		if err != nil {
-			return fmt.Errorf("failed to get transactions: %w", err)
-		}
+	}
+
+	details, err := r.svc.Transaction.GetTransactionDetails(transactions)
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
 	}
 
 	var viewItems []views.TransactionListItem
 
-	for _, tx := range transactions {
-		detail, err := r.svc.Transaction.GetTransactionByID(tx.ID)
-		if err != nil {
-			pterm.Warning.Printf("Skipping transaction %d: %v\n", tx.ID, err)
-			continue
-		}
+	for i, tx := range transactions {
+		detail := details[i]
 
 		txTypeEnum, err := r.svc.Transaction.DetermineType(detail.Splits)
 		txType := string(txTypeEnum)
@@ -88,13 +123,15 @@ func (r *listRunner) Run() error {
 
 		amountCents, currency := r.svc.Transaction.GetDisplayAmount(detail.Splits)
 
-		amountFloat := float64(amountCents) / 100.0
-		amountStr := fmt.Sprintf("%.2f %s", amountFloat, currency)
+		amountStr := commodity.FormatWithCode(amountCents, currency)
 
 		date := time.Unix(tx.Timestamp, 0).Format("2006-01-02")
 		status := "Cleared"
-		if tx.Status == 0 {
+		switch tx.Status {
+		case model.StatusPending:
 			status = "Pending"
+		case model.StatusReconciled:
+			status = "Reconciled"
 		}
 
 		viewItems = append(viewItems, views.TransactionListItem{
@@ -105,12 +142,87 @@ func (r *listRunner) Run() error {
 			Description: tx.Description,
 			Amount:      amountStr,
 			Status:      status,
+			RawAmount:   amountCents,
+			Currency:    currency,
 		})
 	}
 
-	if err := views.NewTransactionListView().Render(viewItems, r.flags.Limit); err != nil {
+	if err := views.NewTransactionListView().Render(viewItems, page.Offset, page.Total); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (r *listRunner) buildFilter() (service.TransactionFilter, error) {
+	if r.flags.Limit < 0 || r.flags.Offset < 0 {
+		return service.TransactionFilter{}, fmt.Errorf("--limit and --offset must not be negative")
+	}
+
+	filter := service.TransactionFilter{
+		Text:      r.flags.Search,
+		Pattern:   r.flags.Regex,
+		Account:   r.flags.Account,
+		Currency:  strings.ToUpper(r.flags.Currency),
+		SortBy:    strings.ToLower(r.flags.Sort),
+		Ascending: r.flags.Asc,
+		Limit:     r.flags.Limit,
+		Offset:    r.flags.Offset,
+	}
+
+	var err error
+	if r.flags.From != "" {
+		if filter.From, err = utils.ParseDateStart(r.flags.From); err != nil {
+			return filter, err
+		}
+	}
+	if r.flags.To != "" {
+		if filter.To, err = utils.ParseDateEnd(r.flags.To); err != nil {
+			return filter, err
+		}
+	}
+
+	currency := filter.Currency
+	if currency == "" {
+		currency = r.svc.Config.Defaults.Currency
+	}
+	if filter.MinAmount, err = parseAmountFlag(r.flags.MinAmount, currency); err != nil {
+		return filter, fmt.Errorf("invalid --min-amount: %w", err)
+	}
+	if filter.MaxAmount, err = parseAmountFlag(r.flags.MaxAmount, currency); err != nil {
+		return filter, fmt.Errorf("invalid --max-amount: %w", err)
+	}
+
+	if r.flags.Type != "" {
+		if filter.Type, err = service.ParseTransactionType(r.flags.Type); err != nil {
+			return filter, err
+		}
+	}
+
+	for _, s := range r.flags.Status {
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "pending":
+			filter.Statuses = append(filter.Statuses, model.StatusPending)
+		case "cleared":
+			filter.Statuses = append(filter.Statuses, model.StatusCleared)
+		case "reconciled":
+			filter.Statuses = append(filter.Statuses, model.StatusReconciled)
+		default:
+			return filter, fmt.Errorf("unknown status '%s' (must be pending, cleared or reconciled)", s)
+		}
+	}
+
+	return filter, nil
+}
+
+// parseAmountFlag parses an optional amount flag, nil when it is empty.
+func parseAmountFlag(s, currency string) (*int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	amount, err := commodity.Parse(s, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &amount, nil
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/hance08/kea/internal/service"
 	"github.com/hance08/kea/internal/ui/views"
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
@@ -35,8 +34,7 @@ func (r *showRunner) Run(args []string) error {
 
 	detail, err := r.svc.Transaction.GetTransactionByID(txID)
 	if err != nil {
-		pterm.Error.Printf("Failed to get transaction: %v\n", err)
-		return nil
+		return fmt.Errorf("failed to get transaction: %w", err)
 	}
 
 	if err := views.RenderTransactionDetail(detail); err != nil {
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui/tui"
+	"github.com/hance08/kea/internal/ui/views"
+	"github.com/spf13/cobra"
+)
+
+type tuiRunner struct {
+	svc *service.Service
+}
+
+func NewTUICmd(svc *service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "tui",
+		Short: "Open the full-screen dashboard",
+		Long: `Open a full-screen dashboard with the account tree, the register of the
+selected account and the net worth, refreshed as the ledger changes.
+
+Keys:
+  tab, ←/→     switch between the account tree and the register
+  ↑/↓, pgup/pgdn, g/G
+               move through the focused pane
+  a            add a transaction to the selected account
+  e, enter     edit the selected transaction
+  d            delete the selected transaction
+  c            mark the selected transaction as cleared, or pending again
+  r            reload
+  q            quit`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner := &tuiRunner{svc: svc}
+			return runner.Run()
+		},
+	}
+}
+
+func (r *tuiRunner) Run() error {
+	if views.Structured() {
+		return errors.New("the dashboard is interactive and cannot be used with --output")
+	}
+	return tui.Run(r.svc)
+}
@@ -32,6 +32,11 @@ func NewApp(cfg *config.Config, migrationFS fs.FS) (*App, func(), error) {
 
 	svc := service.NewService(dbStore, cfg)
 
+	if err := svc.Commodity.LoadRegistry(); err != nil {
+		_ = dbStore.Close()
+		return nil, nil, fmt.Errorf("failed to load commodities: %w", err)
+	}
+
 	cleanup := func() {
 		if err := dbStore.Close(); err != nil {
 			fmt.Printf("Error closing DB: %v\n", err)
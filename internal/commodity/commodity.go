@@ -0,0 +1,87 @@
+// Package commodity holds the process-wide registry of commodity precisions
+// and formats and parses amounts accordingly.
+package commodity
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/utils"
+)
+
+const (
+	// DefaultPrecision applies to codes that are not registered. Every
+	// amount was stored with two decimal places before the registry existed.
+	DefaultPrecision = 2
+
+	// MaxPrecision keeps amounts of up to ~92 billion units within int64.
+	MaxPrecision = 8
+)
+
+var (
+	mu         sync.RWMutex
+	precisions = map[string]int{}
+)
+
+// Load replaces the registry with the given commodities.
+func Load(commodities []*model.Commodity) {
+	m := make(map[string]int, len(commodities))
+	for _, c := range commodities {
+		m[strings.ToUpper(c.Code)] = c.Precision
+	}
+
+	mu.Lock()
+	precisions = m
+	mu.Unlock()
+}
+
+// Precision returns the number of decimal places amounts in code use.
+func Precision(code string) int {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if p, ok := precisions[strings.ToUpper(code)]; ok {
+		return p
+	}
+	return DefaultPrecision
+}
+
+// Format formats an amount in minor units of code, e.g. "1500.50" for USD
+// or "1500" for JPY.
+func Format(amount int64, code string) string {
+	return utils.FormatAmount(amount, Precision(code))
+}
+
+// FormatWithCode formats an amount followed by its code, e.g. "1500.50 USD".
+func FormatWithCode(amount int64, code string) string {
+	return Format(amount, code) + " " + code
+}
+
+// Parse parses a decimal amount into minor units of code, rejecting input
+// with more decimal places than code allows.
+func Parse(amountStr, code string) (int64, error) {
+	return utils.ParseAmount(amountStr, Precision(code))
+}
+
+// Convert converts an amount in minor units of from into minor units of to,
+// where rate is the number of to units per one from unit. The result is
+// rounded half away from zero.
+func Convert(amount int64, from, to string, rate *big.Rat) int64 {
+	scaled := new(big.Rat).Set(rate)
+
+	diff := Precision(to) - Precision(from)
+	if diff != 0 {
+		exp := big.NewInt(int64(diff))
+		exp.Abs(exp)
+		factor := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), exp, nil))
+		if diff > 0 {
+			scaled.Mul(scaled, factor)
+		} else {
+			scaled.Quo(scaled, factor)
+		}
+	}
+
+	return utils.ApplyRate(amount, scaled)
+}
@@ -3,6 +3,8 @@ package config
 type Config struct {
 	Database   DatabaseConfig `mapstructure:"database"`
 	Defaults   DefaultsConfig `mapstructure:"defaults"`
+	Import     ImportConfig   `mapstructure:"import"`
+	Server     ServerConfig   `mapstructure:"server"`
 	ConfigPath string         `mapstructure:"-"`
 }
 
@@ -14,6 +16,34 @@ type DefaultsConfig struct {
 	Currency string `mapstructure:"currency"`
 }
 
+type ServerConfig struct {
+	// Token is the bearer token API clients send; 'kea serve' refuses to
+	// start without one.
+	Token string `mapstructure:"token"`
+}
+
+type ImportConfig struct {
+	// Profiles maps a profile name (lowercased by viper) to its CSV layout.
+	Profiles map[string]CSVProfile `mapstructure:"profiles"`
+}
+
+// CSVProfile describes how to read one bank's CSV export.
+// Columns are referenced by their header names. Either AmountColumn (signed,
+// positive means money into Account) or DebitColumn/CreditColumn (money out /
+// money in) must be set.
+type CSVProfile struct {
+	Account           string `mapstructure:"account"`
+	CounterAccount    string `mapstructure:"counter_account"`
+	DateColumn        string `mapstructure:"date_column"`
+	DescriptionColumn string `mapstructure:"description_column"`
+	AmountColumn      string `mapstructure:"amount_column"`
+	DebitColumn       string `mapstructure:"debit_column"`
+	CreditColumn      string `mapstructure:"credit_column"`
+	DateFormat        string `mapstructure:"date_format"`
+	DecimalSeparator  string `mapstructure:"decimal_separator"`
+	Delimiter         string `mapstructure:"delimiter"`
+}
+
 func NewDefault() *Config {
 	return &Config{
 		Database: DatabaseConfig{Path: ""},
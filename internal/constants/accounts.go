@@ -5,12 +5,15 @@ const (
 )
 
 const (
-	MaxNameLen   = 100
-	CentsPerUnit = 100
+	MaxNameLen      = 100
+	MaxCommodityLen = 10
 )
 
 const (
 	SystemAccountOpeningBalance = "Equity:OpeningBalances"
+	SystemAccountUncategorized  = "Expenses:Uncategorized"
+	SystemAccountCapitalGains   = "Revenue:CapitalGains"
+	SystemAccountFX             = "Revenue:FX"
 	TypeEquity                  = "C"
 	OpeningAccountMemo          = "Opening Balance"
 )
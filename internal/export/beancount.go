@@ -0,0 +1,171 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+)
+
+// beancountRoots maps kea root names onto Beancount's five fixed roots.
+var beancountRoots = map[string]string{
+	"Assets":      "Assets",
+	"Liabilities": "Liabilities",
+	"Equity":      "Equity",
+	"Revenue":     "Income",
+	"Expenses":    "Expenses",
+}
+
+// WriteBeancount writes the journal in Beancount format. Every account gets
+// an open directive dated at its first split, constrained to its currency
+// and every other currency its postings use, e.g. the currencies of the
+// opening balances booked on Equity:OpeningBalances.
+func WriteBeancount(w io.Writer, journal *service.Journal, operatingCurrency string) error {
+	names := beancountAccountNames(journal.Accounts)
+
+	firstUse := make(map[string]int64)
+	used := make(map[string]map[string]bool)
+	for _, tx := range journal.Transactions {
+		for _, split := range tx.Splits {
+			if _, ok := firstUse[split.AccountName]; !ok {
+				firstUse[split.AccountName] = tx.Timestamp
+			}
+			if used[split.AccountName] == nil {
+				used[split.AccountName] = make(map[string]bool)
+			}
+			used[split.AccountName][postingCurrency(split)] = true
+		}
+	}
+
+	// Accounts without any split are opened with the earliest transaction.
+	defaultOpen := time.Now().Unix()
+	if len(journal.Transactions) > 0 {
+		defaultOpen = journal.Transactions[0].Timestamp
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "; Exported from kea on %s\n\n", time.Now().Format("2006-01-02"))
+	fmt.Fprintf(bw, "option \"title\" \"kea\"\n")
+	fmt.Fprintf(bw, "option \"operating_currency\" \"%s\"\n\n", operatingCurrency)
+
+	for _, acc := range journal.Accounts {
+		openDate, ok := firstUse[acc.Name]
+		if !ok {
+			openDate = defaultOpen
+		}
+		currencies := []string{acc.Currency}
+		for currency := range used[acc.Name] {
+			if currency != acc.Currency {
+				currencies = append(currencies, currency)
+			}
+		}
+		sort.Strings(currencies[1:])
+		fmt.Fprintf(bw, "%s open %s %s\n", utils.FormatDate(openDate), names[acc.Name], strings.Join(currencies, ","))
+	}
+
+	for _, tx := range journal.Transactions {
+		fmt.Fprintf(bw, "\n%s %s %s\n",
+			utils.FormatDate(tx.Timestamp),
+			beancountFlag(tx.Status),
+			beancountString(tx.Description),
+		)
+
+		for _, split := range tx.Splits {
+			fmt.Fprintf(bw, "  %-40s  %s\n",
+				names[split.AccountName],
+				postingAmount(split),
+			)
+			if memo := singleLine(split.Memo); memo != "" {
+				fmt.Fprintf(bw, "    memo: %s\n", beancountString(memo))
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func beancountFlag(status int) string {
+	if status == model.StatusPending {
+		return "!"
+	}
+	return "*"
+}
+
+func beancountString(s string) string {
+	s = singleLine(s)
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// beancountAccountNames maps every kea account name to a valid, unique
+// Beancount account name.
+func beancountAccountNames(accounts []*model.Account) map[string]string {
+	names := make(map[string]string, len(accounts))
+	used := make(map[string]bool, len(accounts))
+
+	for _, acc := range accounts {
+		name := beancountAccountName(acc.Name)
+		candidate := name
+		for i := 2; used[candidate]; i++ {
+			candidate = fmt.Sprintf("%s-%d", name, i)
+		}
+		used[candidate] = true
+		names[acc.Name] = candidate
+	}
+
+	return names
+}
+
+// beancountAccountName maps the root and sanitizes every other segment.
+func beancountAccountName(name string) string {
+	segments := strings.Split(name, ":")
+
+	root, ok := beancountRoots[segments[0]]
+	if !ok {
+		root = "Equity"
+	}
+
+	result := []string{root}
+	for _, segment := range segments[1:] {
+		result = append(result, sanitizeBeancountSegment(segment))
+	}
+	return strings.Join(result, ":")
+}
+
+// sanitizeBeancountSegment turns a name segment into Beancount's component
+// syntax: starts with a capital letter or digit, followed by letters, digits
+// or dashes. Words are joined in CamelCase ("credit card" -> "CreditCard").
+func sanitizeBeancountSegment(segment string) string {
+	var b strings.Builder
+	upperNext := true
+
+	for _, r := range segment {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if upperNext {
+				r = unicode.ToUpper(r)
+				upperNext = false
+			}
+			b.WriteRune(r)
+		case r == '-' && b.Len() > 0:
+			b.WriteRune(r)
+		default:
+			upperNext = true
+		}
+	}
+
+	result := strings.TrimRight(b.String(), "-")
+	if result == "" {
+		return "X"
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+// Package export writes the kea ledger in plain-text accounting formats.
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+)
+
+var multiSpace = regexp.MustCompile(`\s{2,}`)
+
+// WriteLedger writes the journal in Ledger-CLI / hledger format. Account and
+// commodity directives are included so the output also passes strict checks.
+func WriteLedger(w io.Writer, journal *service.Journal) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "; Exported from kea on %s\n\n", time.Now().Format("2006-01-02"))
+
+	for _, currency := range journalCurrencies(journal) {
+		fmt.Fprintf(bw, "commodity %s %s\n", commoditySample(currency), currency)
+	}
+	bw.WriteString("\n")
+
+	for _, acc := range journal.Accounts {
+		fmt.Fprintf(bw, "account %s\n", ledgerAccountName(acc.Name))
+	}
+
+	for _, tx := range journal.Transactions {
+		fmt.Fprintf(bw, "\n%s %s %s\n",
+			utils.FormatDate(tx.Timestamp),
+			ledgerStatusMarker(tx.Status),
+			singleLine(tx.Description),
+		)
+
+		for _, split := range tx.Splits {
+			line := fmt.Sprintf("    %-40s  %s",
+				ledgerAccountName(split.AccountName),
+				postingAmount(split),
+			)
+			if memo := singleLine(split.Memo); memo != "" {
+				line += "  ; " + memo
+			}
+			bw.WriteString(line + "\n")
+		}
+	}
+
+	return bw.Flush()
+}
+
+// commoditySample formats 1000 units of code with its registry precision, so
+// ledger and hledger display the commodity with that many decimals.
+func commoditySample(code string) string {
+	amount := int64(1000)
+	for range commodity.Precision(code) {
+		amount *= 10
+	}
+	return commodity.Format(amount, code)
+}
+
+// ledgerStatusMarker maps kea statuses onto ledger's two markers.
+func ledgerStatusMarker(status int) string {
+	if status == model.StatusPending {
+		return "!"
+	}
+	return "*"
+}
+
+// ledgerAccountName collapses whitespace runs, since two spaces end an
+// account name in ledger syntax.
+func ledgerAccountName(name string) string {
+	return multiSpace.ReplaceAllString(strings.TrimSpace(name), " ")
+}
+
+// postingAmount renders a split's amount with its cost as a total price
+// annotation, which both ledger and beancount use to balance postings in
+// different currencies.
+//
+// Revaluation splits carry a cost without an amount. A zero amount at a
+// total price has no weight in either tool, so they are written as a plain
+// amount in the cost currency instead.
+func postingAmount(split service.SplitDetail) string {
+	if split.CostAmount == nil {
+		return fmt.Sprintf("%12s %s", commodity.Format(split.Amount, split.Currency), split.Currency)
+	}
+	if isRevaluation(split) {
+		return fmt.Sprintf("%12s %s", commodity.Format(*split.CostAmount, split.CostCurrency), split.CostCurrency)
+	}
+	return fmt.Sprintf("%12s %s @@ %s %s",
+		commodity.Format(split.Amount, split.Currency), split.Currency,
+		commodity.Format(utils.AbsInt64(*split.CostAmount), split.CostCurrency), split.CostCurrency)
+}
+
+// postingCurrency returns the currency postingAmount writes the split in.
+func postingCurrency(split service.SplitDetail) string {
+	if isRevaluation(split) {
+		return split.CostCurrency
+	}
+	return split.Currency
+}
+
+func isRevaluation(split service.SplitDetail) bool {
+	return split.Amount == 0 && split.CostAmount != nil
+}
+
+func singleLine(s string) string {
+	s = strings.ReplaceAll(s, "\r", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.TrimSpace(s)
+}
+
+func journalCurrencies(journal *service.Journal) []string {
+	seen := make(map[string]bool)
+	for _, acc := range journal.Accounts {
+		seen[acc.Currency] = true
+	}
+	for _, tx := range journal.Transactions {
+		for _, split := range tx.Splits {
+			seen[split.Currency] = true
+			seen[split.CostCurrency] = true
+		}
+	}
+
+	currencies := make([]string, 0, len(seen))
+	for c := range seen {
+		if c != "" {
+			currencies = append(currencies, c)
+		}
+	}
+	sort.Strings(currencies)
+	return currencies
+}
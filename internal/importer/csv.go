@@ -0,0 +1,169 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/utils"
+)
+
+// ParseCSV reads a bank CSV export laid out as described by profile.
+// The first row must be the header row. Amounts are parsed with the
+// precision of currency.
+func ParseCSV(r io.Reader, profile config.CSVProfile, currency string) ([]Record, error) {
+	if err := ValidateCSVProfile(profile); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if profile.Delimiter != "" {
+		reader.Comma = []rune(profile.Delimiter)[0]
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("csv file is empty")
+		}
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	lookup := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		idx, ok := columns[strings.ToLower(name)]
+		if !ok {
+			return -1, fmt.Errorf("column '%s' not found in csv header", name)
+		}
+		return idx, nil
+	}
+
+	dateIdx, err := lookup(profile.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+	descIdx, err := lookup(profile.DescriptionColumn)
+	if err != nil {
+		return nil, err
+	}
+	amountIdx, err := lookup(profile.AmountColumn)
+	if err != nil {
+		return nil, err
+	}
+	debitIdx, err := lookup(profile.DebitColumn)
+	if err != nil {
+		return nil, err
+	}
+	creditIdx, err := lookup(profile.CreditColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	dateFormat := profile.DateFormat
+	if dateFormat == "" {
+		dateFormat = constants.DateFormat
+	}
+
+	field := func(row []string, idx int) string {
+		if idx < 0 || idx >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[idx])
+	}
+
+	var records []Record
+	seen := make(map[string]int)
+	line := 1
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+
+		t, err := time.Parse(dateFormat, field(row, dateIdx))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date '%s' (expected format %s)", line, field(row, dateIdx), dateFormat)
+		}
+
+		var amount int64
+		if amountIdx >= 0 {
+			amount, err = ParseAmount(field(row, amountIdx), profile.DecimalSeparator, currency)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		} else {
+			debit, err := ParseAmount(field(row, debitIdx), profile.DecimalSeparator, currency)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			credit, err := ParseAmount(field(row, creditIdx), profile.DecimalSeparator, currency)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			amount = utils.AbsInt64(credit) - utils.AbsInt64(debit)
+		}
+
+		if amount == 0 {
+			continue
+		}
+
+		desc := field(row, descIdx)
+		if desc == "" {
+			desc = "-"
+		}
+
+		timestamp := t.Unix()
+		key := fmt.Sprintf("%d|%d|%s", timestamp, amount, desc)
+		seen[key]++
+
+		records = append(records, Record{
+			Line:        line,
+			Timestamp:   timestamp,
+			Description: desc,
+			Amount:      amount,
+			ExternalID:  externalID("csv", profile.Account, timestamp, amount, desc, seen[key]),
+		})
+	}
+
+	return records, nil
+}
+
+// ValidateCSVProfile checks that a profile has everything ParseCSV needs.
+func ValidateCSVProfile(profile config.CSVProfile) error {
+	if profile.Account == "" {
+		return fmt.Errorf("profile is missing 'account'")
+	}
+	if profile.DateColumn == "" {
+		return fmt.Errorf("profile is missing 'date_column'")
+	}
+	if profile.AmountColumn == "" && profile.DebitColumn == "" && profile.CreditColumn == "" {
+		return fmt.Errorf("profile needs 'amount_column' or 'debit_column'/'credit_column'")
+	}
+	if profile.DecimalSeparator != "" && profile.DecimalSeparator != "." && profile.DecimalSeparator != "," {
+		return fmt.Errorf("decimal_separator must be '.' or ','")
+	}
+	return nil
+}
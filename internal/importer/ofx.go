@@ -0,0 +1,51 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/ofx"
+)
+
+// RecordsFromOFX converts statement entries into records, parsing amounts
+// with the precision of currency. The bank's FITID is used as external_id
+// (scoped by the bank account ID, since FITIDs are only unique per account).
+// Entries without a FITID get an ID from their content, so the same entry
+// keeps its ID when a later statement overlaps this one.
+func RecordsFromOFX(stmt ofx.Statement, currency string) ([]Record, error) {
+	records := make([]Record, 0, len(stmt.Transactions))
+	seen := make(map[string]int)
+
+	for i, trn := range stmt.Transactions {
+		desc := strings.TrimSpace(trn.Name)
+		if desc == "" {
+			desc = strings.TrimSpace(trn.Memo)
+		}
+		if desc == "" {
+			desc = "-"
+		}
+
+		amount, err := commodity.Parse(trn.Amount, currency)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i+1, err)
+		}
+
+		id := "ofx:" + stmt.AccountID + ":" + trn.FITID
+		if trn.FITID == "" {
+			key := fmt.Sprintf("%d|%d|%s", trn.Posted.Unix(), amount, desc)
+			seen[key]++
+			id = externalID("ofx", stmt.AccountID, trn.Posted.Unix(), amount, desc, seen[key])
+		}
+
+		records = append(records, Record{
+			Line:        i + 1,
+			Timestamp:   trn.Posted.Unix(),
+			Description: desc,
+			Amount:      amount,
+			ExternalID:  id,
+		})
+	}
+
+	return records, nil
+}
@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hance08/kea/internal/constants"
+)
+
+// PriceRecord is one row of a price CSV file.
+type PriceRecord struct {
+	Line  int
+	Date  int64
+	Base  string
+	Quote string
+	Rate  string
+}
+
+// ParsePriceCSV reads rows of date,base,quote,rate such as
+// "2025-03-01,EUR,USD,1.0873". A header row is skipped if present.
+func ParsePriceCSV(r io.Reader) ([]PriceRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var records []PriceRecord
+	line := 0
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		if len(row) != 4 {
+			return nil, fmt.Errorf("line %d: expected 4 columns (date,base,quote,rate), got %d", line, len(row))
+		}
+
+		dateStr := strings.TrimSpace(strings.TrimPrefix(row[0], "\ufeff"))
+		t, err := time.Parse(constants.DateFormat, dateStr)
+		if err != nil {
+			if line == 1 {
+				continue // header row
+			}
+			return nil, fmt.Errorf("line %d: invalid date '%s', use YYYY-MM-DD", line, dateStr)
+		}
+
+		records = append(records, PriceRecord{
+			Line:  line,
+			Date:  t.Unix(),
+			Base:  strings.TrimSpace(row[1]),
+			Quote: strings.TrimSpace(row[2]),
+			Rate:  strings.TrimSpace(row[3]),
+		})
+	}
+
+	return records, nil
+}
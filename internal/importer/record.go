@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hance08/kea/internal/commodity"
+)
+
+// Record is one statement line, normalized from the point of view of the
+// imported account: a positive Amount means money flowed into that account.
+type Record struct {
+	Line        int
+	Timestamp   int64
+	Description string
+	Amount      int64
+	ExternalID  string
+}
+
+// externalID derives a deterministic ID from the row content. occurrence
+// distinguishes identical rows within the same file (e.g. two coffees on
+// the same day), so re-importing the file yields the same IDs.
+func externalID(prefix, account string, timestamp int64, amount int64, description string, occurrence int) string {
+	key := fmt.Sprintf("%s|%d|%d|%s|%d", account, timestamp, amount, strings.TrimSpace(description), occurrence)
+	sum := sha256.Sum256([]byte(key))
+	return prefix + ":" + hex.EncodeToString(sum[:16])
+}
+
+// ParseAmount parses a bank formatted amount into minor units of currency.
+// It accepts a leading sign or accounting parentheses, and drops thousands
+// separators based on decimalSeparator ("." or ",").
+func ParseAmount(raw, decimalSeparator, currency string) (int64, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return 0, nil
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	if strings.HasPrefix(s, "-") {
+		negative = !negative
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	s = strings.ReplaceAll(s, " ", "")
+	if decimalSeparator == "," {
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.ReplaceAll(s, ",", ".")
+	} else {
+		s = strings.ReplaceAll(s, ",", "")
+	}
+
+	amount, err := commodity.Parse(s, currency)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount '%s': %w", raw, err)
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
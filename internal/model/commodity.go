@@ -0,0 +1,8 @@
+package model
+
+// Commodity is a currency or other unit amounts are held in. Amounts are
+// stored as integers in units of 10^-Precision.
+type Commodity struct {
+	Code      string
+	Precision int
+}
@@ -0,0 +1,26 @@
+package model
+
+// Lot is a quantity of a commodity bought in a single transaction. Sales
+// are matched against lots to work out their cost basis.
+type Lot struct {
+	ID           int64
+	AccountID    int64
+	SplitID      int64
+	Date         int64
+	Quantity     int64
+	CostAmount   int64 // total cost of Quantity
+	CostCurrency string
+
+	// Remaining and RemainingCost are what is left after earlier sales.
+	Remaining     int64
+	RemainingCost int64
+}
+
+// LotDisposal records the part of a lot consumed by one sale.
+type LotDisposal struct {
+	ID         int64
+	LotID      int64
+	SplitID    int64
+	Quantity   int64
+	CostAmount int64
+}
@@ -0,0 +1,10 @@
+package model
+
+// Price is the value of one unit of Base expressed in Quote on Date.
+type Price struct {
+	ID    int64
+	Date  int64
+	Base  string
+	Quote string
+	Rate  string
+}
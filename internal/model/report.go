@@ -0,0 +1,16 @@
+package model
+
+// MonthlyTotal is the sum of split amounts of one account within one calendar month.
+type MonthlyTotal struct {
+	AccountID int64
+	Month     string // YYYY-MM
+	Amount    int64
+}
+
+// CostTotal sums the splits in Currency that carry a cost in CostCurrency.
+type CostTotal struct {
+	Currency     string
+	Amount       int64
+	CostCurrency string
+	CostAmount   int64
+}
@@ -0,0 +1,12 @@
+package model
+
+// Rule picks a counter account for a transaction. Nil conditions always match.
+type Rule struct {
+	ID                 int64
+	Priority           int
+	DescriptionPattern string
+	MinAmount          *int64
+	MaxAmount          *int64
+	SourceAccountID    *int64
+	TargetAccountID    int64
+}
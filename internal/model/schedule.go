@@ -0,0 +1,37 @@
+package model
+
+// Recurrence frequencies of scheduled transactions.
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+)
+
+// ScheduledTransaction is a transaction template posted on every occurrence
+// of its recurrence, from StartDate until EndDate.
+type ScheduledTransaction struct {
+	ID          int64
+	Description string
+	Frequency   string
+	Interval    int  // every Interval days, weeks, months or years
+	DayOfMonth  *int // monthly and yearly only; nil uses the day of StartDate
+	StartDate   int64
+	EndDate     *int64 // nil repeats forever
+
+	// AutoPost posts occurrences as cleared, otherwise they are created as
+	// pending for the user to confirm.
+	AutoPost bool
+}
+
+// ScheduledSplit is one split of the transaction a schedule posts.
+type ScheduledSplit struct {
+	ID           int64
+	ScheduleID   int64
+	AccountID    int64
+	Amount       int64
+	Currency     string
+	Memo         string
+	CostAmount   *int64
+	CostCurrency string
+}
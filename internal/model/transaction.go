@@ -21,4 +21,50 @@ type Split struct {
 	Amount        int64
 	Currency      string
 	Memo          string
+
+	// CostAmount is the value of the split in CostCurrency, set when the
+	// transaction spans several currencies. Nil means the split is
+	// balanced in its own currency.
+	CostAmount   *int64
+	CostCurrency string
+}
+
+// Weight returns the amount and currency the split contributes to the
+// transaction balance.
+func (s Split) Weight() (int64, string) {
+	if s.CostAmount != nil {
+		return *s.CostAmount, s.CostCurrency
+	}
+	return s.Amount, s.Currency
+}
+
+// AccountSplit is a split joined with the account it is posted to.
+type AccountSplit struct {
+	Split
+	AccountName string
+	AccountType string
+	HasLot      bool // the split opened or sold an investment lot
+}
+
+// AccountEntry is one split of an account joined with its transaction header.
+type AccountEntry struct {
+	TransactionID int64
+	Timestamp     int64
+	Description   string
+	Status        int
+	SplitID       int64
+	Amount        int64
+	Currency      string
+	Memo          string
+	CostAmount    *int64
+	CostCurrency  string
+}
+
+// Reconciliation records a completed reconciliation of an account against a statement.
+type Reconciliation struct {
+	ID            int64
+	AccountID     int64
+	StatementDate int64
+	EndingBalance int64
+	CreatedAt     int64
 }
@@ -0,0 +1,189 @@
+// Package ofx parses OFX/QFX bank statements. Both SGML (OFX 1.x, where leaf
+// elements have no closing tags) and XML (OFX 2.x) files are supported by
+// scanning tags sequentially instead of building a full document tree.
+package ofx
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+// Statement is one bank or credit card statement (STMTRS / CCSTMTRS).
+type Statement struct {
+	Currency      string
+	AccountID     string
+	Transactions  []Transaction
+	LedgerBalance *Balance
+}
+
+// Transaction is one STMTTRN entry. Amount is a decimal string with '.' as
+// separator, signed from the account holder's point of view (negative =
+// money out). It is left unparsed because its precision depends on the
+// currency of the account it is imported into.
+type Transaction struct {
+	Type   string
+	Posted time.Time
+	Amount string
+	FITID  string
+	Name   string
+	Memo   string
+}
+
+type Balance struct {
+	Amount string
+	AsOf   time.Time
+}
+
+// Parse reads every statement contained in an OFX document.
+func Parse(r io.Reader) ([]Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ofx: %w", err)
+	}
+
+	content := string(data)
+	start := strings.Index(strings.ToUpper(content), "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("not an OFX document: <OFX> element not found")
+	}
+	content = content[start:]
+
+	var (
+		statements []Statement
+		stmt       *Statement
+		trn        *Transaction
+		ledger     *Balance
+	)
+
+	for len(content) > 0 {
+		open := strings.IndexByte(content, '<')
+		if open < 0 {
+			break
+		}
+		closeIdx := strings.IndexByte(content[open:], '>')
+		if closeIdx < 0 {
+			return nil, fmt.Errorf("malformed OFX: unterminated tag")
+		}
+
+		tag := strings.ToUpper(strings.TrimSpace(content[open+1 : open+closeIdx]))
+		content = content[open+closeIdx+1:]
+
+		next := strings.IndexByte(content, '<')
+		if next < 0 {
+			next = len(content)
+		}
+		value := strings.TrimSpace(html.UnescapeString(content[:next]))
+
+		if tag == "" || tag[0] == '?' || tag[0] == '!' {
+			continue
+		}
+
+		switch tag {
+		case "STMTRS", "CCSTMTRS":
+			statements = append(statements, Statement{})
+			stmt = &statements[len(statements)-1]
+			continue
+		case "/STMTRS", "/CCSTMTRS":
+			stmt = nil
+			continue
+		case "STMTTRN":
+			trn = &Transaction{}
+			continue
+		case "/STMTTRN":
+			if stmt != nil && trn != nil {
+				stmt.Transactions = append(stmt.Transactions, *trn)
+			}
+			trn = nil
+			continue
+		case "LEDGERBAL":
+			ledger = &Balance{}
+			continue
+		case "/LEDGERBAL":
+			if stmt != nil && ledger != nil {
+				stmt.LedgerBalance = ledger
+			}
+			ledger = nil
+			continue
+		}
+
+		if strings.HasPrefix(tag, "/") || value == "" || stmt == nil {
+			continue
+		}
+
+		switch {
+		case trn != nil:
+			if err := setTransactionField(trn, tag, value); err != nil {
+				return nil, err
+			}
+		case ledger != nil:
+			if err := setBalanceField(ledger, tag, value); err != nil {
+				return nil, err
+			}
+		default:
+			switch tag {
+			case "CURDEF":
+				stmt.Currency = strings.ToUpper(value)
+			case "ACCTID":
+				stmt.AccountID = value
+			}
+		}
+	}
+
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no statement found in OFX document")
+	}
+
+	return statements, nil
+}
+
+func setTransactionField(trn *Transaction, tag, value string) error {
+	var err error
+	switch tag {
+	case "TRNTYPE":
+		trn.Type = value
+	case "DTPOSTED":
+		trn.Posted, err = parseDate(value)
+	case "TRNAMT":
+		trn.Amount = normalizeAmount(value)
+	case "FITID":
+		trn.FITID = value
+	case "NAME":
+		trn.Name = value
+	case "MEMO":
+		trn.Memo = value
+	}
+	return err
+}
+
+func setBalanceField(bal *Balance, tag, value string) error {
+	var err error
+	switch tag {
+	case "BALAMT":
+		bal.Amount = normalizeAmount(value)
+	case "DTASOF":
+		bal.AsOf, err = parseDate(value)
+	}
+	return err
+}
+
+// parseDate reads the date part of an OFX datetime (YYYYMMDD[HHMMSS[.XXX]][TZ]).
+// The time of day is dropped so it lines up with kea's day-based dates.
+func parseDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid OFX date '%s'", value)
+	}
+	t, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid OFX date '%s': %w", value, err)
+	}
+	return t, nil
+}
+
+// normalizeAmount turns an OFX decimal into one using '.' as the decimal
+// separator. Some banks use ',', which the spec allows.
+func normalizeAmount(value string) string {
+	return strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+}
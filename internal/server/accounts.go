@@ -0,0 +1,278 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/validation"
+)
+
+type accountJSON struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+	ParentID    *int64 `json:"parent_id"`
+	Archived    bool   `json:"archived"`
+	Balance     string `json:"balance"`
+
+	// OtherBalances holds amounts in other currencies, keyed by currency.
+	OtherBalances map[string]string `json:"other_balances,omitempty"`
+
+	// ConvertedBalance is in ConvertedCurrency, nil without a price.
+	ConvertedBalance  *string `json:"converted_balance"`
+	ConvertedCurrency string  `json:"converted_currency"`
+}
+
+type accountListResponse struct {
+	Accounts []accountJSON `json:"accounts"`
+}
+
+// accountRequest creates an account the way 'kea account create' does: Name
+// is the last segment, placed under Parent or under the root of Type.
+type accountRequest struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Parent      string `json:"parent"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+	Balance     string `json:"balance"`
+}
+
+// accountUpdate renames, moves, archives or unarchives an account, one at a
+// time.
+type accountUpdate struct {
+	Name     *string `json:"name"`
+	Parent   *string `json:"parent"`
+	Archived *bool   `json:"archived"`
+	Force    bool    `json:"force"` // archive accounts that hold a balance
+}
+
+func newAccountJSON(b service.AccountBalance, target string) accountJSON {
+	acc := b.Account
+	item := accountJSON{
+		ID:                acc.ID,
+		Name:              acc.Name,
+		Type:              acc.Type,
+		Currency:          acc.Currency,
+		Description:       acc.Description,
+		ParentID:          acc.ParentID,
+		Archived:          acc.IsHidden,
+		Balance:           commodity.Format(b.Balance, acc.Currency),
+		ConvertedCurrency: target,
+	}
+	for code, amount := range b.Other {
+		if item.OtherBalances == nil {
+			item.OtherBalances = make(map[string]string, len(b.Other))
+		}
+		item.OtherBalances[code] = commodity.Format(amount, code)
+	}
+	if b.Converted != nil {
+		converted := commodity.Format(*b.Converted, target)
+		item.ConvertedBalance = &converted
+	}
+	return item
+}
+
+// listAccounts returns accounts with their balances. Query parameters:
+// type (A, L, C, R or E) and archived=true to include archived accounts.
+func (s *Server) listAccounts(r *http.Request) (int, any, error) {
+	query := r.URL.Query()
+
+	var accounts []*model.Account
+	var err error
+	if accType := query.Get("type"); accType != "" {
+		accounts, err = s.svc.Account.GetAccountsByType(strings.ToUpper(accType))
+	} else {
+		accounts, err = s.svc.Account.GetAllAccounts()
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if query.Get("archived") != "true" {
+		visible := accounts[:0]
+		for _, acc := range accounts {
+			if !acc.IsHidden {
+				visible = append(visible, acc)
+			}
+		}
+		accounts = visible
+	}
+
+	converter, err := s.svc.Price.NewConverter()
+	if err != nil {
+		return 0, nil, err
+	}
+	balances, err := s.svc.Account.GetAccountBalances(accounts, converter)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	out := accountListResponse{Accounts: make([]accountJSON, 0, len(balances))}
+	for _, b := range balances {
+		out.Accounts = append(out.Accounts, newAccountJSON(b, converter.Target()))
+	}
+	return http.StatusOK, out, nil
+}
+
+func (s *Server) getAccount(r *http.Request) (int, any, error) {
+	account, err := s.svc.Account.GetAccountByName(r.PathValue("name"))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	out, err := s.accountResponse(account)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, out, nil
+}
+
+func (s *Server) createAccount(r *http.Request) (int, any, error) {
+	var req accountRequest
+	if err := decode(r, &req); err != nil {
+		return 0, nil, err
+	}
+
+	if (req.Type == "") == (req.Parent == "") {
+		return 0, nil, badRequest("give either type or parent")
+	}
+
+	validator := validation.NewAccountValidator()
+	if err := validator.ValidateAccountName(req.Name); err != nil {
+		return 0, nil, badRequest("invalid account name: %v", err)
+	}
+
+	var prefix, accType, currency string
+	var parentID *int64
+
+	if req.Parent != "" {
+		parent, err := s.svc.Account.GetAccountByName(req.Parent)
+		if err != nil {
+			return 0, nil, err
+		}
+		prefix, accType, currency = parent.Name, parent.Type, parent.Currency
+		parentID = &parent.ID
+	} else {
+		root, err := s.svc.Account.GetRootNameByType(req.Type)
+		if err != nil {
+			return 0, nil, badRequest("%v", err)
+		}
+		prefix, accType, currency = root, strings.ToUpper(req.Type), s.svc.Config.Defaults.Currency
+	}
+
+	if req.Currency != "" {
+		if err := validator.ValidateCurrency(req.Currency); err != nil {
+			return 0, nil, badRequest("%v", err)
+		}
+		currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+	}
+
+	fullName := s.svc.Account.FormatAccountName(prefix, req.Name)
+	if err := validator.ValidateFullAccountName(fullName); err != nil {
+		return 0, nil, badRequest("invalid account name: %v", err)
+	}
+
+	var balance int64
+	if req.Balance != "" {
+		var err error
+		if balance, err = commodity.Parse(req.Balance, currency); err != nil {
+			return 0, nil, badRequest("invalid balance '%s' for %s: %v", req.Balance, currency, err)
+		}
+	}
+
+	account, err := s.svc.CreateAccountWithBalance(fullName, accType, currency, req.Description, parentID, balance)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	out, err := s.accountResponse(account)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, out, nil
+}
+
+func (s *Server) updateAccount(r *http.Request) (int, any, error) {
+	var req accountUpdate
+	if err := decode(r, &req); err != nil {
+		return 0, nil, err
+	}
+
+	changes := 0
+	for _, set := range []bool{req.Name != nil, req.Parent != nil, req.Archived != nil} {
+		if set {
+			changes++
+		}
+	}
+	if changes != 1 {
+		return 0, nil, badRequest("give exactly one of name, parent or archived")
+	}
+
+	name := r.PathValue("name")
+	var renames []service.AccountRename
+	var err error
+
+	switch {
+	case req.Name != nil:
+		renames, err = s.svc.Account.RenameAccount(name, *req.Name)
+	case req.Parent != nil:
+		renames, err = s.svc.Account.MoveAccount(name, *req.Parent)
+	case *req.Archived:
+		_, err = s.svc.Account.ArchiveAccount(name, req.Force)
+	default:
+		_, err = s.svc.Account.UnarchiveAccount(name)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// The account itself comes first among the renames
+	if len(renames) > 0 {
+		name = renames[0].NewName
+	}
+
+	account, err := s.svc.Account.GetAccountByName(name)
+	if err != nil {
+		return 0, nil, err
+	}
+	out, err := s.accountResponse(account)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, out, nil
+}
+
+// deleteAccount deletes an unused account, or merges it into the account
+// given by the reassign_to query parameter.
+func (s *Server) deleteAccount(r *http.Request) (int, any, error) {
+	_, err := s.svc.Account.DeleteAccount(r.PathValue("name"), r.URL.Query().Get("reassign_to"))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+// accountResponse returns account with its balance.
+func (s *Server) accountResponse(account *model.Account) (*accountJSON, error) {
+	converter, err := s.svc.Price.NewConverter()
+	if err != nil {
+		return nil, err
+	}
+	balances, err := s.svc.Account.GetAccountBalances([]*model.Account{account}, converter)
+	if err != nil {
+		return nil, err
+	}
+	if len(balances) == 0 {
+		return nil, errors.New("failed to load account balance")
+	}
+
+	item := newAccountJSON(balances[0], converter.Target())
+	return &item, nil
+}
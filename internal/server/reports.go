@@ -0,0 +1,267 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+)
+
+// sectionJSON is one section of a report with natural signs, in the report
+// currency.
+type sectionJSON struct {
+	Title    string     `json:"title"`
+	Type     string     `json:"type"`
+	Total    string     `json:"total"`
+	Accounts []lineJSON `json:"accounts"`
+}
+
+type lineJSON struct {
+	Name    string `json:"name"`
+	Balance string `json:"balance"` // own splits only
+	Total   string `json:"total"`   // including sub-accounts
+}
+
+type balancesResponse struct {
+	Currency     string        `json:"currency"`
+	Sections     []sectionJSON `json:"sections"`
+	NetWorth     string        `json:"net_worth"`
+	MissingRates bool          `json:"missing_rates"`
+}
+
+type balanceSheetResponse struct {
+	Date            string      `json:"date"`
+	Currency        string      `json:"currency"`
+	Assets          sectionJSON `json:"assets"`
+	Liabilities     sectionJSON `json:"liabilities"`
+	Equity          sectionJSON `json:"equity"`
+	CurrentEarnings string      `json:"current_earnings"`
+	Translation     string      `json:"translation"`
+	Difference      string      `json:"difference"`
+}
+
+type incomeStatementResponse struct {
+	From      string      `json:"from"`
+	To        string      `json:"to"`
+	Currency  string      `json:"currency"`
+	Revenue   sectionJSON `json:"revenue"`
+	Expenses  sectionJSON `json:"expenses"`
+	NetIncome string      `json:"net_income"`
+}
+
+type trendResponse struct {
+	Type         string         `json:"type"`
+	Period       string         `json:"period"`
+	Currency     string         `json:"currency"`
+	Periods      []string       `json:"periods"`
+	Accounts     []trendRowJSON `json:"accounts"`
+	ColumnTotals []string       `json:"column_totals"`
+	Total        string         `json:"total"`
+	Average      string         `json:"average"`
+}
+
+// trendRowJSON holds one account's amounts, aligned with trendResponse.Periods.
+type trendRowJSON struct {
+	Account string   `json:"account"`
+	Amounts []string `json:"amounts"`
+	Total   string   `json:"total"`
+	Average string   `json:"average"`
+}
+
+func newSectionJSON(section service.ReportSection, currency string) sectionJSON {
+	out := sectionJSON{
+		Title:    section.Title,
+		Type:     section.Type,
+		Total:    commodity.Format(section.Total, currency),
+		Accounts: []lineJSON{},
+	}
+	for _, node := range section.Flatten() {
+		out.Accounts = append(out.Accounts, lineJSON{
+			Name:    node.Account.Name,
+			Balance: commodity.Format(node.Balance, currency),
+			Total:   commodity.Format(node.Total, currency),
+		})
+	}
+	return out
+}
+
+// getBalances returns the current balances of all visible accounts as a
+// tree per account type, converted into the default currency.
+func (s *Server) getBalances(r *http.Request) (int, any, error) {
+	accounts, err := s.svc.Account.GetAllAccounts()
+	if err != nil {
+		return 0, nil, err
+	}
+	visible := make([]*model.Account, 0, len(accounts))
+	for _, acc := range accounts {
+		if !acc.IsHidden {
+			visible = append(visible, acc)
+		}
+	}
+
+	converter, err := s.svc.Price.NewConverter()
+	if err != nil {
+		return 0, nil, err
+	}
+	tree, err := s.svc.Account.GetAccountTree(visible, converter)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	out := balancesResponse{
+		Currency:     tree.Currency,
+		Sections:     make([]sectionJSON, 0, len(tree.Sections)),
+		MissingRates: tree.MissingRates,
+	}
+	var netWorth int64
+	for _, section := range tree.Sections {
+		out.Sections = append(out.Sections, newSectionJSON(section, tree.Currency))
+		switch section.Type {
+		case "A":
+			netWorth += section.Total
+		case "L":
+			netWorth -= section.Total
+		}
+	}
+	out.NetWorth = commodity.Format(netWorth, tree.Currency)
+
+	return http.StatusOK, out, nil
+}
+
+// getBalanceSheet takes the cutoff date from the date query parameter,
+// default is today.
+func (s *Server) getBalanceSheet(r *http.Request) (int, any, error) {
+	cutoff, err := dateParam(r, "date", false, time.Now())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	sheet, err := s.svc.Report.GetBalanceSheet(cutoff)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return http.StatusOK, balanceSheetResponse{
+		Date:            utils.FormatDate(sheet.Date),
+		Currency:        sheet.Currency,
+		Assets:          newSectionJSON(sheet.Assets, sheet.Currency),
+		Liabilities:     newSectionJSON(sheet.Liabilities, sheet.Currency),
+		Equity:          newSectionJSON(sheet.Equity, sheet.Currency),
+		CurrentEarnings: commodity.Format(sheet.CurrentEarnings, sheet.Currency),
+		Translation:     commodity.Format(sheet.Translation, sheet.Currency),
+		Difference:      commodity.Format(sheet.Difference, sheet.Currency),
+	}, nil
+}
+
+// getIncomeStatement covers from (default the first day of this month) to
+// to (default today).
+func (s *Server) getIncomeStatement(r *http.Request) (int, any, error) {
+	now := time.Now()
+	from, err := dateParam(r, "from", true, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return 0, nil, err
+	}
+	to, err := dateParam(r, "to", false, now)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	statement, err := s.svc.Report.GetIncomeStatement(from, to)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return http.StatusOK, incomeStatementResponse{
+		From:      utils.FormatDate(statement.From),
+		To:        utils.FormatDate(statement.To),
+		Currency:  statement.Currency,
+		Revenue:   newSectionJSON(statement.Revenue, statement.Currency),
+		Expenses:  newSectionJSON(statement.Expenses, statement.Currency),
+		NetIncome: commodity.Format(statement.NetIncome, statement.Currency),
+	}, nil
+}
+
+// getTrend takes type (default E), period (month, quarter or year, default
+// month), from (default the first day of this year) and to (default today).
+func (s *Server) getTrend(r *http.Request) (int, any, error) {
+	query := r.URL.Query()
+
+	accType := strings.ToUpper(query.Get("type"))
+	if accType == "" {
+		accType = "E"
+	}
+	if _, err := s.svc.Account.GetRootNameByType(accType); err != nil {
+		return 0, nil, badRequest("%v", err)
+	}
+
+	period := strings.ToLower(query.Get("period"))
+	if period == "" {
+		period = service.PeriodMonth
+	}
+
+	from, err := dateParam(r, "from", true, time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return 0, nil, err
+	}
+	to, err := dateParam(r, "to", false, time.Now())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	report, err := s.svc.Report.GetTrend(accType, period, from, to)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	format := func(amounts []int64) []string {
+		formatted := make([]string, len(amounts))
+		for i, amount := range amounts {
+			formatted[i] = commodity.Format(amount, report.Currency)
+		}
+		return formatted
+	}
+
+	out := trendResponse{
+		Type:         report.Type,
+		Period:       report.Period,
+		Currency:     report.Currency,
+		Periods:      append([]string{}, report.Periods...),
+		Accounts:     make([]trendRowJSON, 0, len(report.Rows)),
+		ColumnTotals: format(report.ColumnTotals),
+		Total:        commodity.Format(report.GrandTotal, report.Currency),
+		Average:      commodity.Format(report.Average, report.Currency),
+	}
+	for _, row := range report.Rows {
+		out.Accounts = append(out.Accounts, trendRowJSON{
+			Account: row.AccountName,
+			Amounts: format(row.Amounts),
+			Total:   commodity.Format(row.Total, report.Currency),
+			Average: commodity.Format(row.Average, report.Currency),
+		})
+	}
+	return http.StatusOK, out, nil
+}
+
+// dateParam reads a YYYY-MM-DD query parameter as the start or the end of
+// that day, using the day of fallback when it is missing.
+func dateParam(r *http.Request, key string, start bool, fallback time.Time) (int64, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		value = fallback.Format(constants.DateFormat)
+	}
+
+	parse := utils.ParseDateEnd
+	if start {
+		parse = utils.ParseDateStart
+	}
+	timestamp, err := parse(value)
+	if err != nil {
+		return 0, badRequest("invalid %s: %v", key, err)
+	}
+	return timestamp, nil
+}
@@ -0,0 +1,179 @@
+// Package server serves the ledger as a JSON API over HTTP, for dashboards
+// and scripts that post transactions from other devices.
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/store"
+)
+
+// maxBodySize caps request bodies; a transaction is a few hundred bytes.
+const maxBodySize = 1 << 20
+
+// Server routes API requests to the services. Every request needs the
+// bearer token.
+type Server struct {
+	svc   *service.Service
+	token string
+	mux   *http.ServeMux
+
+	// mu lets reads run side by side but writes one at a time, since the
+	// services check their rules before writing.
+	mu sync.RWMutex
+}
+
+func New(svc *service.Service, token string) *Server {
+	s := &Server{
+		svc:   svc,
+		token: token,
+		mux:   http.NewServeMux(),
+	}
+	s.routes()
+	return s
+}
+
+func (s *Server) routes() {
+	s.handle("GET /api/accounts", s.listAccounts)
+	s.handle("POST /api/accounts", s.createAccount)
+	s.handle("GET /api/accounts/{name}", s.getAccount)
+	s.handle("PATCH /api/accounts/{name}", s.updateAccount)
+	s.handle("DELETE /api/accounts/{name}", s.deleteAccount)
+
+	s.handle("GET /api/transactions", s.listTransactions)
+	s.handle("POST /api/transactions", s.createTransaction)
+	s.handle("GET /api/transactions/{id}", s.getTransaction)
+	s.handle("PUT /api/transactions/{id}", s.updateTransaction)
+	s.handle("DELETE /api/transactions/{id}", s.deleteTransaction)
+
+	s.handle("GET /api/balances", s.getBalances)
+	s.handle("GET /api/reports/balance-sheet", s.getBalanceSheet)
+	s.handle("GET /api/reports/income", s.getIncomeStatement)
+	s.handle("GET /api/reports/trend", s.getTrend)
+}
+
+// handlerFunc returns the status and body of a successful response, or an
+// error that is turned into an error response by statusOf.
+type handlerFunc func(r *http.Request) (int, any, error)
+
+// handle registers h for pattern, holding the read lock for GET requests
+// and the write lock for everything else.
+func (s *Server) handle(pattern string, h handlerFunc) {
+	method, _, _ := strings.Cut(pattern, " ")
+
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if method == http.MethodGet {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+		} else {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+		}
+
+		status, body, err := h(r)
+		if err != nil {
+			writeError(w, statusOf(err), err)
+			return
+		}
+		writeJSON(w, status, body)
+	})
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="kea"`)
+		writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// httpError is an error with the status it should be reported with.
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+func (e *httpError) Unwrap() error {
+	return e.err
+}
+
+// badRequest marks err as a malformed request.
+func badRequest(format string, a ...any) error {
+	return &httpError{status: http.StatusBadRequest, err: fmt.Errorf(format, a...)}
+}
+
+// unprocessable marks err as a well-formed request the ledger refuses.
+func unprocessable(format string, a ...any) error {
+	return &httpError{status: http.StatusUnprocessableEntity, err: fmt.Errorf(format, a...)}
+}
+
+// statusOf maps an error to its HTTP status. Requests the ledger refuses,
+// e.g. an unbalanced transaction, are reported as 422; errors that are not
+// about the request, such as a failing database, are reported as 500.
+func statusOf(err error) int {
+	var httpErr *httpError
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.status
+	case errors.Is(err, store.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, store.ErrAccountExists), errors.Is(err, store.ErrDuplicateExternalID):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalid), errors.Is(err, store.ErrConstraintViolation):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type errorResponse struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Message string `json:"message"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: errorDetail{Message: err.Error()}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+// decode reads a JSON request body into v, rejecting unknown fields so that
+// typos do not pass silently.
+func decode(r *http.Request, v any) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest("invalid request body: %v", err)
+	}
+	return nil
+}
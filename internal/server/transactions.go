@@ -0,0 +1,356 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+)
+
+type transactionJSON struct {
+	ID          int64       `json:"id"`
+	Date        string      `json:"date"`
+	Description string      `json:"description"`
+	Status      string      `json:"status"`
+	Type        string      `json:"type"`
+	Splits      []splitJSON `json:"splits"`
+}
+
+// splitJSON keeps the sign of the amount: debits are positive, credits
+// negative.
+type splitJSON struct {
+	ID           int64   `json:"id"`
+	Account      string  `json:"account"`
+	Amount       string  `json:"amount"`
+	Currency     string  `json:"currency"`
+	Memo         string  `json:"memo"`
+	CostAmount   *string `json:"cost_amount"`
+	CostCurrency string  `json:"cost_currency"`
+}
+
+type transactionListResponse struct {
+	Offset       int               `json:"offset"`
+	Total        int               `json:"total"`
+	Transactions []transactionJSON `json:"transactions"`
+}
+
+// transactionRequest is a service.TransactionInput with amounts written as
+// decimals in the currency of each split's account.
+type transactionRequest struct {
+	Date        string         `json:"date"` // YYYY-MM-DD, default is today
+	Description string         `json:"description"`
+	Status      string         `json:"status"` // pending or cleared (default)
+	Splits      []splitRequest `json:"splits"`
+}
+
+type splitRequest struct {
+	// ID keeps an existing split when a transaction is replaced; splits
+	// left out are deleted.
+	ID           int64  `json:"id"`
+	Account      string `json:"account"`
+	Amount       string `json:"amount"`
+	Memo         string `json:"memo"`
+	CostAmount   string `json:"cost_amount"`
+	CostCurrency string `json:"cost_currency"`
+}
+
+var statusNames = map[int]string{
+	model.StatusPending:    "Pending",
+	model.StatusCleared:    "Cleared",
+	model.StatusReconciled: "Reconciled",
+}
+
+func (s *Server) newTransactionJSON(detail *service.TransactionDetail) transactionJSON {
+	txType, err := s.svc.Transaction.DetermineType(detail.Splits)
+	if err != nil {
+		txType = service.TxTypeOther
+	}
+
+	item := transactionJSON{
+		ID:          detail.ID,
+		Date:        utils.FormatDate(detail.Timestamp),
+		Description: detail.Description,
+		Status:      statusNames[detail.Status],
+		Type:        string(txType),
+		Splits:      make([]splitJSON, 0, len(detail.Splits)),
+	}
+
+	for _, split := range detail.Splits {
+		var cost *string
+		if split.CostAmount != nil {
+			formatted := commodity.Format(*split.CostAmount, split.CostCurrency)
+			cost = &formatted
+		}
+		item.Splits = append(item.Splits, splitJSON{
+			ID:           split.ID,
+			Account:      split.AccountName,
+			Amount:       commodity.Format(split.Amount, split.Currency),
+			Currency:     split.Currency,
+			Memo:         split.Memo,
+			CostAmount:   cost,
+			CostCurrency: split.CostCurrency,
+		})
+	}
+	return item
+}
+
+// listTransactions searches transactions with the filters of 'kea tx list':
+// from, to, account ("Name:*" for sub-accounts), search, regex, status
+// (repeatable), type, currency, min_amount, max_amount, sort, asc, limit and
+// offset. Without a limit, 50 transactions are returned.
+func (s *Server) listTransactions(r *http.Request) (int, any, error) {
+	filter, err := s.transactionFilter(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	page, err := s.svc.Transaction.SearchTransactions(filter)
+	if err != nil {
+		return 0, nil, err
+	}
+	details, err := s.svc.Transaction.GetTransactionDetails(page.Transactions)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	out := transactionListResponse{
+		Offset:       page.Offset,
+		Total:        page.Total,
+		Transactions: make([]transactionJSON, 0, len(details)),
+	}
+	for _, detail := range details {
+		out.Transactions = append(out.Transactions, s.newTransactionJSON(detail))
+	}
+	return http.StatusOK, out, nil
+}
+
+func (s *Server) transactionFilter(r *http.Request) (service.TransactionFilter, error) {
+	query := r.URL.Query()
+
+	filter := service.TransactionFilter{
+		Text:      query.Get("search"),
+		Pattern:   query.Get("regex"),
+		Account:   query.Get("account"),
+		Currency:  strings.ToUpper(query.Get("currency")),
+		SortBy:    strings.ToLower(query.Get("sort")),
+		Ascending: query.Get("asc") == "true",
+		Limit:     50,
+	}
+
+	var err error
+	for key, target := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if v := query.Get(key); v != "" {
+			if *target, err = strconv.Atoi(v); err != nil || *target < 0 {
+				return filter, badRequest("invalid %s '%s'", key, v)
+			}
+		}
+	}
+
+	if v := query.Get("from"); v != "" {
+		if filter.From, err = utils.ParseDateStart(v); err != nil {
+			return filter, badRequest("invalid from: %v", err)
+		}
+	}
+	if v := query.Get("to"); v != "" {
+		if filter.To, err = utils.ParseDateEnd(v); err != nil {
+			return filter, badRequest("invalid to: %v", err)
+		}
+	}
+
+	currency := filter.Currency
+	if currency == "" {
+		currency = s.svc.Config.Defaults.Currency
+	}
+	for key, target := range map[string]**int64{"min_amount": &filter.MinAmount, "max_amount": &filter.MaxAmount} {
+		if v := query.Get(key); v != "" {
+			amount, err := commodity.Parse(v, currency)
+			if err != nil {
+				return filter, badRequest("invalid %s: %v", key, err)
+			}
+			*target = &amount
+		}
+	}
+
+	if v := query.Get("type"); v != "" {
+		if filter.Type, err = service.ParseTransactionType(v); err != nil {
+			return filter, badRequest("%v", err)
+		}
+	}
+
+	for _, v := range query["status"] {
+		status, err := parseStatus(v, true)
+		if err != nil {
+			return filter, err
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	return filter, nil
+}
+
+func (s *Server) getTransaction(r *http.Request) (int, any, error) {
+	detail, err := s.transactionByID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, s.newTransactionJSON(detail), nil
+}
+
+func (s *Server) createTransaction(r *http.Request) (int, any, error) {
+	var req transactionRequest
+	if err := decode(r, &req); err != nil {
+		return 0, nil, err
+	}
+
+	input, err := s.transactionInput(req)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	txID, err := s.svc.Transaction.CreateTransaction(input)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	detail, err := s.svc.Transaction.GetTransactionByID(txID)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, s.newTransactionJSON(detail), nil
+}
+
+// updateTransaction replaces a transaction with the request, under the same
+// rules as 'kea tx edit'.
+func (s *Server) updateTransaction(r *http.Request) (int, any, error) {
+	detail, err := s.transactionByID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !s.svc.Transaction.IsEditable(detail) {
+		return 0, nil, unprocessable("transaction #%d cannot be edited (system, reconciled or investment transaction)", detail.ID)
+	}
+
+	var req transactionRequest
+	if err := decode(r, &req); err != nil {
+		return 0, nil, err
+	}
+
+	input, err := s.transactionInput(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	if req.Date == "" {
+		input.Timestamp = detail.Timestamp
+	}
+
+	err = s.svc.Transaction.UpdateTransactionComplete(detail.ID, input.Description, input.Timestamp, input.Status, input.Splits)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	updated, err := s.svc.Transaction.GetTransactionByID(detail.ID)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, s.newTransactionJSON(updated), nil
+}
+
+func (s *Server) deleteTransaction(r *http.Request) (int, any, error) {
+	txID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return 0, nil, badRequest("invalid transaction ID '%s'", r.PathValue("id"))
+	}
+
+	if err := s.svc.Transaction.DeleteTransaction(txID); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) transactionByID(r *http.Request) (*service.TransactionDetail, error) {
+	txID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil, badRequest("invalid transaction ID '%s'", r.PathValue("id"))
+	}
+	return s.svc.Transaction.GetTransactionByID(txID)
+}
+
+// transactionInput resolves the accounts of a request and parses its
+// amounts in their currencies.
+func (s *Server) transactionInput(req transactionRequest) (service.TransactionInput, error) {
+	input := service.TransactionInput{
+		Description: req.Description,
+		Status:      model.StatusCleared,
+	}
+
+	if req.Date != "" {
+		timestamp, err := utils.ParseDateStart(req.Date)
+		if err != nil {
+			return input, badRequest("invalid date: %v", err)
+		}
+		input.Timestamp = timestamp
+	}
+	if req.Status != "" {
+		status, err := parseStatus(req.Status, false)
+		if err != nil {
+			return input, err
+		}
+		input.Status = status
+	}
+
+	for i, split := range req.Splits {
+		account, err := s.svc.Account.GetAccountByName(split.Account)
+		if err != nil {
+			return input, badRequest("split #%d: %v", i+1, err)
+		}
+
+		amount, err := commodity.Parse(split.Amount, account.Currency)
+		if err != nil {
+			return input, badRequest("split #%d: invalid amount '%s' for %s: %v", i+1, split.Amount, account.Currency, err)
+		}
+
+		splitInput := service.TransactionSplitInput{
+			ID:          split.ID,
+			AccountName: account.Name,
+			AccountID:   account.ID,
+			Amount:      amount,
+			Currency:    account.Currency,
+			Memo:        split.Memo,
+		}
+
+		if split.CostAmount != "" {
+			code := strings.ToUpper(strings.TrimSpace(split.CostCurrency))
+			if code == "" {
+				return input, badRequest("split #%d: cost_amount needs a cost_currency", i+1)
+			}
+			cost, err := commodity.Parse(split.CostAmount, code)
+			if err != nil {
+				return input, badRequest("split #%d: invalid cost amount '%s' for %s: %v", i+1, split.CostAmount, code, err)
+			}
+			splitInput.CostAmount = &cost
+			splitInput.CostCurrency = code
+		}
+
+		input.Splits = append(input.Splits, splitInput)
+	}
+
+	return input, nil
+}
+
+// parseStatus parses a status name. Reconciled is only accepted in filters,
+// since reconciling goes through 'kea reconcile'.
+func parseStatus(s string, allowReconciled bool) (int, error) {
+	for status, name := range statusNames {
+		if strings.EqualFold(name, strings.TrimSpace(s)) && (allowReconciled || status != model.StatusReconciled) {
+			return status, nil
+		}
+	}
+	if allowReconciled {
+		return 0, badRequest("unknown status '%s' (must be pending, cleared or reconciled)", s)
+	}
+	return 0, badRequest("unknown status '%s' (must be pending or cleared)", s)
+}
@@ -2,8 +2,15 @@ package service
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/constants"
 	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/store"
+	"github.com/hance08/kea/internal/validation"
 )
 
 func (as *AccountService) CreateAccount(name, accType, currency, description string, parentID *int64) (*model.Account, error) {
@@ -23,16 +30,27 @@ func (as *AccountService) CreateAccount(name, accType, currency, description str
 	}, nil
 }
 
+// CreateAccountWithBalance creates an account and books its opening balance
+// in one database transaction, so neither is left behind without the other.
 func (s *Service) CreateAccountWithBalance(name, accType, currency, description string, parentID *int64, balance int64) (*model.Account, error) {
-	account, err := s.Account.CreateAccount(name, accType, currency, description, parentID)
-	if err != nil {
-		return nil, err
-	}
+	var account *model.Account
 
-	if balance != 0 {
-		if err := s.Transaction.CreateOpeningBalance(account, balance); err != nil {
-			return account, fmt.Errorf("account created but failed to set opening balance: %w", err)
+	err := s.Account.repo.ExecTx(func(repo store.Repository) error {
+		var err error
+		account, err = NewAccountService(repo, s.Config).CreateAccount(name, accType, currency, description, parentID)
+		if err != nil {
+			return err
 		}
+
+		if balance != 0 {
+			if err := s.Transaction.createOpeningBalance(repo, account, balance); err != nil {
+				return fmt.Errorf("failed to set opening balance: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return account, nil
@@ -44,3 +62,427 @@ func (as *AccountService) FormatAccountName(prefix, name string) string {
 	}
 	return prefix + ":" + name
 }
+
+// AccountRename is an account whose full name changed.
+type AccountRename struct {
+	ID      int64
+	OldName string
+	NewName string
+}
+
+// RenameAccount changes the last segment of an account's name, e.g.
+// Assets:Bank to Assets:Checking. newName is either the new segment or the
+// full new name under the same parent. Sub-accounts are renamed with it.
+func (as *AccountService) RenameAccount(oldName, newName string) ([]AccountRename, error) {
+	account, err := as.repo.GetAccountByName(oldName)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRelocatable(account); err != nil {
+		return nil, err
+	}
+
+	parentPath, _ := splitAccountName(account.Name)
+
+	newName = strings.TrimSpace(newName)
+	if !strings.Contains(newName, ":") {
+		newName = as.FormatAccountName(parentPath, newName)
+	}
+
+	newParentPath, leaf := splitAccountName(newName)
+	if newParentPath != parentPath {
+		return nil, invalid("'%s' is not under %s, use 'kea account move' to change the parent", newName, parentPath)
+	}
+	if err := validation.NewAccountValidator().ValidateAccountName(leaf); err != nil {
+		return nil, invalid("invalid account name: %w", err)
+	}
+	if newName == account.Name {
+		return nil, invalid("account is already named %s", newName)
+	}
+
+	return as.relocate(account, newName, account.ParentID)
+}
+
+// MoveAccount moves an account and its sub-accounts under newParent, which
+// is an existing account or a root such as "Assets". The new parent must be
+// of the same type, since moving would otherwise change the account's type.
+func (as *AccountService) MoveAccount(name, newParent string) ([]AccountRename, error) {
+	account, err := as.repo.GetAccountByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRelocatable(account); err != nil {
+		return nil, err
+	}
+
+	newParent = strings.TrimSpace(newParent)
+
+	var parentID *int64
+	parentType, root, isRoot := rootType(newParent)
+	if isRoot {
+		newParent = root
+	} else {
+		parent, err := as.repo.GetAccountByName(newParent)
+		if err != nil {
+			return nil, err
+		}
+		if parent.ID == account.ID || strings.HasPrefix(parent.Name, account.Name+":") {
+			return nil, invalid("cannot move %s under itself", account.Name)
+		}
+		parentType = parent.Type
+		parentID = &parent.ID
+	}
+
+	if parentType != account.Type {
+		return nil, invalid("cannot move %s (type %s) under %s (type %s): an account cannot change its type",
+			account.Name, account.Type, newParent, parentType)
+	}
+
+	_, leaf := splitAccountName(account.Name)
+	newName := as.FormatAccountName(newParent, leaf)
+	if newName == account.Name {
+		return nil, invalid("%s is already under %s", account.Name, newParent)
+	}
+
+	return as.relocate(account, newName, parentID)
+}
+
+// relocate gives an account a new full name and parent and rewrites the full
+// names of all its descendants in a single database transaction.
+func (as *AccountService) relocate(account *model.Account, newName string, parentID *int64) ([]AccountRename, error) {
+	accounts, err := as.repo.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	renames, parents, err := as.planRelocation(accounts, account, newName, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = as.repo.ExecTx(func(repo store.Repository) error {
+		return applyRelocation(repo, renames, parents)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renames, nil
+}
+
+// planRelocation works out the new full names of an account and its
+// descendants, and the parent each of them ends up with, refusing names that
+// are invalid or already taken.
+func (as *AccountService) planRelocation(accounts []*model.Account, account *model.Account, newName string, parentID *int64) ([]AccountRename, map[int64]*int64, error) {
+	children := make(map[int64][]*model.Account)
+	existing := make(map[string]bool, len(accounts))
+	for _, acc := range accounts {
+		if acc.ParentID != nil {
+			children[*acc.ParentID] = append(children[*acc.ParentID], acc)
+		}
+		existing[acc.Name] = true
+	}
+
+	renames := []AccountRename{{ID: account.ID, OldName: account.Name, NewName: newName}}
+	parents := map[int64]*int64{account.ID: parentID}
+
+	// Walk the subtree through parent_id, so each child keeps its own last
+	// segment under its parent's new name.
+	for i := 0; i < len(renames); i++ {
+		for _, child := range children[renames[i].ID] {
+			_, leaf := splitAccountName(child.Name)
+			renames = append(renames, AccountRename{
+				ID:      child.ID,
+				OldName: child.Name,
+				NewName: as.FormatAccountName(renames[i].NewName, leaf),
+			})
+			parents[child.ID] = child.ParentID
+		}
+	}
+
+	validator := validation.NewAccountValidator()
+	for _, r := range renames {
+		if err := validator.ValidateFullAccountName(r.NewName); err != nil {
+			return nil, nil, invalid("invalid account name %s: %w", r.NewName, err)
+		}
+		if existing[r.NewName] {
+			return nil, nil, fmt.Errorf("%w: %s", store.ErrAccountExists, r.NewName)
+		}
+	}
+
+	return renames, parents, nil
+}
+
+func applyRelocation(repo store.Repository, renames []AccountRename, parents map[int64]*int64) error {
+	for _, r := range renames {
+		if err := repo.UpdateAccountPath(r.ID, r.NewName, parents[r.ID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AccountRemoval is an account removed by DeleteAccount or MergeAccount.
+type AccountRemoval struct {
+	Account *model.Account
+	Target  *model.Account  // account the splits moved to, nil for a plain delete
+	Splits  int64           // number of splits moved to Target
+	Renames []AccountRename // sub-accounts moved under Target
+}
+
+// DeleteAccount deletes an account. Accounts with splits, sub-accounts or
+// rules can only be deleted by moving them to reassignTo, as MergeAccount
+// does.
+func (as *AccountService) DeleteAccount(name, reassignTo string) (*AccountRemoval, error) {
+	if reassignTo != "" {
+		return as.MergeAccount(name, reassignTo)
+	}
+
+	account, err := as.repo.GetAccountByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRelocatable(account); err != nil {
+		return nil, err
+	}
+
+	accounts, err := as.repo.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	if len(accountSubtree(accounts, account)) > 1 {
+		return nil, invalid("%s has sub-accounts, reassign them to another account to delete it", account.Name)
+	}
+
+	count, err := as.repo.CountAccountSplits(account.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, invalid("%s has %d split(s), reassign them to another account to delete it", account.Name, count)
+	}
+
+	// Rules would be deleted along with the account
+	rules, err := as.repo.CountAccountRules(account.ID)
+	if err != nil {
+		return nil, err
+	}
+	if rules > 0 {
+		return nil, invalid("%s is used by %d rule(s), reassign them to another account to delete it", account.Name, rules)
+	}
+
+	if err := as.repo.DeleteAccount(account.ID); err != nil {
+		return nil, err
+	}
+	return &AccountRemoval{Account: account}, nil
+}
+
+// MergeAccount moves all splits and sub-accounts of src to dst and deletes
+// src, in a single database transaction. Both accounts must have the same
+// type and currency so the moved splits keep their meaning.
+func (as *AccountService) MergeAccount(srcName, dstName string) (*AccountRemoval, error) {
+	src, err := as.repo.GetAccountByName(srcName)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := as.repo.GetAccountByName(dstName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acc := range []*model.Account{src, dst} {
+		if err := checkRelocatable(acc); err != nil {
+			return nil, err
+		}
+	}
+	if src.ID == dst.ID {
+		return nil, invalid("cannot merge %s into itself", src.Name)
+	}
+	if strings.HasPrefix(dst.Name, src.Name+":") {
+		return nil, invalid("cannot merge %s into its own sub-account %s", src.Name, dst.Name)
+	}
+	if src.Type != dst.Type {
+		return nil, invalid("cannot merge %s (type %s) into %s (type %s): the types differ",
+			src.Name, src.Type, dst.Name, dst.Type)
+	}
+	if src.Currency != dst.Currency {
+		return nil, invalid("cannot merge %s (%s) into %s (%s): the currencies differ",
+			src.Name, src.Currency, dst.Name, dst.Currency)
+	}
+
+	accounts, err := as.repo.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	removal := &AccountRemoval{Account: src, Target: dst}
+	parents := make(map[int64]*int64)
+	for _, child := range accounts {
+		if child.ParentID == nil || *child.ParentID != src.ID {
+			continue
+		}
+		_, leaf := splitAccountName(child.Name)
+		renames, childParents, err := as.planRelocation(accounts, child, as.FormatAccountName(dst.Name, leaf), &dst.ID)
+		if err != nil {
+			return nil, err
+		}
+		removal.Renames = append(removal.Renames, renames...)
+		for id, parentID := range childParents {
+			parents[id] = parentID
+		}
+	}
+
+	err = as.repo.ExecTx(func(repo store.Repository) error {
+		if err := applyRelocation(repo, removal.Renames, parents); err != nil {
+			return err
+		}
+
+		moved, err := repo.ReassignAccount(src.ID, dst.ID)
+		if err != nil {
+			return err
+		}
+		removal.Splits = moved
+
+		return repo.DeleteAccount(src.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return removal, nil
+}
+
+// ArchiveAccount hides an account and its sub-accounts from the account
+// pickers. Their transactions stay in reports. Accounts that still hold a
+// balance are only archived with force.
+func (as *AccountService) ArchiveAccount(name string, force bool) ([]*model.Account, error) {
+	account, err := as.repo.GetAccountByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := as.repo.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	subtree := accountSubtree(accounts, account)
+
+	if !force {
+		balances, err := as.repo.GetAccountBalances()
+		if err != nil {
+			return nil, err
+		}
+		for _, acc := range subtree {
+			var held []string
+			for _, currency := range slices.Sorted(maps.Keys(balances[acc.ID])) {
+				if amount := balances[acc.ID][currency]; amount != 0 {
+					held = append(held, commodity.FormatWithCode(amount*NaturalSign(acc.Type), currency))
+				}
+			}
+			if len(held) > 0 {
+				return nil, invalid("%s has a balance of %s, move it to another account first or archive it by force",
+					acc.Name, strings.Join(held, ", "))
+			}
+		}
+	}
+
+	if err := as.setHidden(subtree, true); err != nil {
+		return nil, err
+	}
+	return subtree, nil
+}
+
+// UnarchiveAccount makes an account and its sub-accounts selectable again,
+// along with any archived parents so the account is reachable.
+func (as *AccountService) UnarchiveAccount(name string) ([]*model.Account, error) {
+	account, err := as.repo.GetAccountByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := as.repo.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	byID := make(map[int64]*model.Account, len(accounts))
+	for _, acc := range accounts {
+		byID[acc.ID] = acc
+	}
+
+	affected := accountSubtree(accounts, account)
+	for parentID := account.ParentID; parentID != nil; {
+		parent, ok := byID[*parentID]
+		if !ok {
+			break
+		}
+		affected = append(affected, parent)
+		parentID = parent.ParentID
+	}
+
+	if err := as.setHidden(affected, false); err != nil {
+		return nil, err
+	}
+	return affected, nil
+}
+
+func (as *AccountService) setHidden(accounts []*model.Account, hidden bool) error {
+	return as.repo.ExecTx(func(repo store.Repository) error {
+		for _, acc := range accounts {
+			if acc.IsHidden == hidden {
+				continue
+			}
+			if err := repo.SetAccountHidden(acc.ID, hidden); err != nil {
+				return err
+			}
+			acc.IsHidden = hidden
+		}
+		return nil
+	})
+}
+
+// accountSubtree returns root followed by all its descendants, linked
+// through parent_id.
+func accountSubtree(accounts []*model.Account, root *model.Account) []*model.Account {
+	children := make(map[int64][]*model.Account)
+	for _, acc := range accounts {
+		if acc.ParentID != nil {
+			children[*acc.ParentID] = append(children[*acc.ParentID], acc)
+		}
+	}
+
+	subtree := []*model.Account{root}
+	for i := 0; i < len(subtree); i++ {
+		subtree = append(subtree, children[subtree[i].ID]...)
+	}
+	return subtree
+}
+
+// checkRelocatable refuses to rename, merge or delete accounts kea looks up
+// by name.
+func checkRelocatable(account *model.Account) error {
+	if account.Name == constants.SystemAccountOpeningBalance {
+		return invalid("operation denied: %s is a system account", account.Name)
+	}
+	return nil
+}
+
+// splitAccountName splits a full name into its parent path and last segment.
+func splitAccountName(name string) (string, string) {
+	i := strings.LastIndex(name, ":")
+	if i < 0 {
+		return "", name
+	}
+	return name[:i], name[i+1:]
+}
+
+// rootType returns the account type and canonical spelling of a root name
+// such as "assets".
+func rootType(name string) (string, string, bool) {
+	for accType, root := range rootNames {
+		if strings.EqualFold(root, name) {
+			return accType, root, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/hance08/kea/internal/model"
+)
+
+// RegisterLine is one split of the account in its register.
+type RegisterLine struct {
+	TransactionID int64
+	Timestamp     int64
+	Description   string
+	Status        int
+	Memo          string
+	Counters      []string // accounts of the other splits of the transaction
+	Amount        int64    // stored sign: debits positive, credits negative
+	Currency      string   // currency of Amount
+	// Balance is the balance per currency after this split, in natural sign.
+	// Currencies whose balance is zero are left out.
+	Balance map[string]int64
+}
+
+// AccountRegister lists the splits of an account in date order. From and To
+// are 0 when the register is not limited on that side. Opening and Closing
+// are the balances per currency before the first and after the last line,
+// in natural sign, like RegisterLine.Balance.
+type AccountRegister struct {
+	Account *model.Account
+	From    int64
+	To      int64
+	Opening map[string]int64
+	Closing map[string]int64
+	Debits  map[string]int64 // sum of the debits of the lines per currency
+	Credits map[string]int64 // sum of the credits of the lines per currency, as positive amounts
+	Lines   []RegisterLine
+}
+
+// GetRegister builds the register of the named account for the splits dated
+// within [from, to]. The opening balance sums every split before from.
+func (as *AccountService) GetRegister(name string, from, to int64) (*AccountRegister, error) {
+	if from != 0 && to != 0 && to < from {
+		return nil, errors.New("the end date is before the start date")
+	}
+
+	account, err := as.repo.GetAccountByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime, endTime := from, to
+	if startTime == 0 {
+		startTime = math.MinInt64
+	}
+	if endTime == 0 {
+		endTime = math.MaxInt64
+	}
+
+	balance := make(map[string]int64)
+	if from != 0 {
+		balances, err := as.repo.GetBalancesAsOf(from - 1)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(balance, balances[account.ID])
+	}
+
+	entries, err := as.repo.GetAccountEntries(account.ID, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDs := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		txIDs = append(txIDs, entry.TransactionID)
+	}
+	splits, err := as.repo.GetSplitsByTransactions(slices.Compact(txIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	sign := NaturalSign(account.Type)
+	register := &AccountRegister{
+		Account: account,
+		From:    from,
+		To:      to,
+		Opening: naturalBalances(balance, sign),
+		Debits:  make(map[string]int64),
+		Credits: make(map[string]int64),
+		Lines:   make([]RegisterLine, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		balance[entry.Currency] += entry.Amount
+		if entry.Amount > 0 {
+			register.Debits[entry.Currency] += entry.Amount
+		} else {
+			register.Credits[entry.Currency] -= entry.Amount
+		}
+
+		var counters []string
+		for _, split := range splits[entry.TransactionID] {
+			if split.AccountID != account.ID && !slices.Contains(counters, split.AccountName) {
+				counters = append(counters, split.AccountName)
+			}
+		}
+
+		register.Lines = append(register.Lines, RegisterLine{
+			TransactionID: entry.TransactionID,
+			Timestamp:     entry.Timestamp,
+			Description:   entry.Description,
+			Status:        entry.Status,
+			Memo:          entry.Memo,
+			Counters:      counters,
+			Amount:        entry.Amount,
+			Currency:      entry.Currency,
+			Balance:       naturalBalances(balance, sign),
+		})
+	}
+	register.Closing = naturalBalances(balance, sign)
+
+	return register, nil
+}
+
+// naturalBalances copies balances in stored sign into natural sign, leaving
+// out the currencies whose balance is zero.
+func naturalBalances(balances map[string]int64, sign int64) map[string]int64 {
+	natural := make(map[string]int64, len(balances))
+	for currency, amount := range balances {
+		if amount != 0 {
+			natural[currency] = amount * sign
+		}
+	}
+	return natural
+}
@@ -1,20 +1,21 @@
 package service
 
 import (
-	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hance08/kea/internal/commodity"
 	"github.com/hance08/kea/internal/config"
 	"github.com/hance08/kea/internal/model"
 	"github.com/hance08/kea/internal/store"
 )
 
 type AccountService struct {
-	repo   store.AccountRepository
+	repo   store.Repository
 	config *config.Config
 }
 
-func NewAccountService(repo store.AccountRepository, cfg *config.Config) *AccountService {
+func NewAccountService(repo store.Repository, cfg *config.Config) *AccountService {
 	return &AccountService{repo: repo, config: cfg}
 }
 
@@ -31,30 +32,148 @@ func (as *AccountService) GetAccountsByType(accType string) ([]*model.Account, e
 }
 
 func (as *AccountService) GetAccountBalanceFormatted(accountID int64) (string, error) {
+	account, err := as.repo.GetAccountByID(accountID)
+	if err != nil {
+		return "", err
+	}
+
 	balance, err := as.repo.GetAccountBalance(accountID)
 	if err != nil {
 		return "", err
 	}
 
-	balanceFloat := float64(balance) / 100
-	return fmt.Sprintf("%.2f", balanceFloat), nil
+	return commodity.Format(balance, account.Currency), nil
+}
+
+// AccountBalance is an account's balance in its own currency and, when a
+// price is available, in the converter's target currency.
+type AccountBalance struct {
+	Account *model.Account
+	Balance int64
+
+	// Other holds amounts in other currencies, as Equity:OpeningBalances gets
+	// from the opening balances of foreign accounts.
+	Other map[string]int64
+
+	Converted *int64 // nil when no rate is known
+}
+
+// GetAccountBalances returns the current balance of every account, converted
+// with the latest known rates.
+func (as *AccountService) GetAccountBalances(accounts []*model.Account, converter *Converter) ([]AccountBalance, error) {
+	balances, err := as.repo.GetAccountBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	result := make([]AccountBalance, 0, len(accounts))
+
+	for _, acc := range accounts {
+		balance, other := splitBalance(acc, balances[acc.ID])
+
+		entry := AccountBalance{Account: acc, Balance: balance, Other: other}
+		if converted, _, err := convertAmounts(converter, balances[acc.ID], now); err == nil {
+			entry.Converted = &converted
+		}
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+// AccountTree is the account hierarchy shown by 'kea account list --tree'.
+// The nodes of Sections are in Currency, with natural signs, while Balances
+// holds each account's own balance in its own currency and Other its amounts
+// in other currencies.
+type AccountTree struct {
+	Currency     string
+	Sections     []ReportSection
+	Balances     map[int64]int64
+	Other        map[int64]map[string]int64
+	MissingRates bool // some balances have no rate and are left out of the totals
+}
+
+// GetAccountTree arranges accounts under their parents, one section per
+// account type, and rolls their balances up into subtree totals.
+func (as *AccountService) GetAccountTree(accounts []*model.Account, converter *Converter) (*AccountTree, error) {
+	balances, err := as.repo.GetAccountBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	tree := &AccountTree{
+		Currency: converter.Target(),
+		Balances: make(map[int64]int64, len(accounts)),
+		Other:    make(map[int64]map[string]int64),
+	}
+
+	converted := make(map[int64]int64, len(balances))
+	for _, acc := range accounts {
+		sign := NaturalSign(acc.Type)
+		balance, other := splitBalance(acc, balances[acc.ID])
+		tree.Balances[acc.ID] = balance * sign
+		if other != nil {
+			for currency := range other {
+				other[currency] *= sign
+			}
+			tree.Other[acc.ID] = other
+		}
+
+		amount, _, err := convertAmounts(converter, balances[acc.ID], now)
+		if err != nil {
+			tree.MissingRates = true
+			continue
+		}
+		converted[acc.ID] = amount
+	}
+
+	for _, accType := range []string{"A", "L", "C", "R", "E"} {
+		section := buildSection(rootNames[accType], accType, accounts, converted)
+		if len(section.Roots) > 0 {
+			tree.Sections = append(tree.Sections, section)
+		}
+	}
+
+	return tree, nil
+}
+
+// splitBalance separates the amounts of an account in its own currency from
+// those in other currencies, leaving out zero amounts. other is nil when the
+// account holds only its own currency.
+func splitBalance(acc *model.Account, amounts map[string]int64) (int64, map[string]int64) {
+	var balance int64
+	var other map[string]int64
+	for currency, amount := range amounts {
+		switch {
+		case currency == acc.Currency:
+			balance = amount
+		case amount != 0:
+			if other == nil {
+				other = make(map[string]int64)
+			}
+			other[currency] = amount
+		}
+	}
+	return balance, other
+}
+
+// rootNames maps each account type to the root of its names.
+var rootNames = map[string]string{
+	"A": "Assets",
+	"L": "Liabilities",
+	"E": "Expenses",
+	"R": "Revenue",
+	"C": "Equity",
 }
 
 func (as *AccountService) GetRootNameByType(accType string) (string, error) {
-	switch strings.ToUpper(accType) {
-	case "A":
-		return "Assets", nil
-	case "L":
-		return "Liabilities", nil
-	case "E":
-		return "Expenses", nil
-	case "R":
-		return "Revenue", nil
-	case "C":
-		return "Equity", nil
-	default:
-		return "", fmt.Errorf("invalid account type '%s' (must be A, L, C, R, E)", accType)
+	root, ok := rootNames[strings.ToUpper(accType)]
+	if !ok {
+		return "", invalid("invalid account type '%s' (must be A, L, C, R, E)", accType)
 	}
+	return root, nil
 }
 
 func (as *AccountService) CheckAccountExists(name string) (bool, error) {
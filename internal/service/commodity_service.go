@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/store"
+)
+
+type CommodityService struct {
+	repo   store.Repository
+	config *config.Config
+}
+
+func NewCommodityService(repo store.Repository, cfg *config.Config) *CommodityService {
+	return &CommodityService{repo: repo, config: cfg}
+}
+
+// LoadRegistry loads the stored precisions into the commodity registry used
+// for parsing and formatting amounts.
+func (cs *CommodityService) LoadRegistry() error {
+	commodities, err := cs.repo.GetCommodities()
+	if err != nil {
+		return err
+	}
+	commodity.Load(commodities)
+	return nil
+}
+
+func (cs *CommodityService) ListCommodities() ([]*model.Commodity, error) {
+	return cs.repo.GetCommodities()
+}
+
+// SetPrecision registers code with the given precision. The precision of a
+// commodity that accounts or transactions already use cannot change, since
+// the stored amounts would change value.
+func (cs *CommodityService) SetPrecision(code string, precision int) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return fmt.Errorf("commodity code is required")
+	}
+
+	if precision < 0 || precision > commodity.MaxPrecision {
+		return fmt.Errorf("precision must be between 0 and %d", commodity.MaxPrecision)
+	}
+
+	if commodity.Precision(code) != precision {
+		inUse, err := cs.repo.IsCommodityInUse(code)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return fmt.Errorf("cannot change precision of %s from %d to %d: it is already used by accounts or transactions",
+				code, commodity.Precision(code), precision)
+		}
+	}
+
+	if err := cs.repo.UpsertCommodity(model.Commodity{Code: code, Precision: precision}); err != nil {
+		return err
+	}
+
+	return cs.LoadRegistry()
+}
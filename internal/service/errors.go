@@ -0,0 +1,32 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalid is matched by errors the ledger returns when it refuses a
+// request, e.g. an unbalanced transaction or an edit to a reconciled one.
+var ErrInvalid = errors.New("invalid request")
+
+// invalidError describes a refused request in its own words while still
+// matching ErrInvalid with errors.Is.
+type invalidError struct {
+	err error
+}
+
+func (e *invalidError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidError) Unwrap() error {
+	return e.err
+}
+
+func (e *invalidError) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+func invalid(format string, a ...any) error {
+	return &invalidError{err: fmt.Errorf(format, a...)}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/store"
+)
+
+type ExportService struct {
+	repo        store.Repository
+	config      *config.Config
+	transaction *TransactionService
+}
+
+// Journal is the data needed to write the ledger in a plain-text format.
+type Journal struct {
+	Accounts     []*model.Account
+	Transactions []*TransactionDetail // oldest first
+}
+
+func NewExportService(repo store.Repository, cfg *config.Config, ts *TransactionService) *ExportService {
+	return &ExportService{repo: repo, config: cfg, transaction: ts}
+}
+
+// GetJournal loads all accounts and every transaction dated within
+// [startTime, endTime], in chronological order.
+func (es *ExportService) GetJournal(startTime, endTime int64) (*Journal, error) {
+	accounts, err := es.repo.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	transactions, err := es.repo.GetTransactionsByDateRange(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].Timestamp != transactions[j].Timestamp {
+			return transactions[i].Timestamp < transactions[j].Timestamp
+		}
+		return transactions[i].ID < transactions[j].ID
+	})
+
+	details, err := es.transaction.GetTransactionDetails(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Journal{
+		Accounts:     accounts,
+		Transactions: details,
+	}, nil
+}
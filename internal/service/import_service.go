@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/importer"
+	"github.com/hance08/kea/internal/store"
+)
+
+type ImportService struct {
+	repo        store.Repository
+	config      *config.Config
+	transaction *TransactionService
+	rule        *RuleService
+}
+
+// ImportResult summarizes an import run.
+type ImportResult struct {
+	Imported int
+	Skipped  int // already in the ledger (duplicate external_id)
+	Failures []ImportFailure
+}
+
+type ImportFailure struct {
+	Line int
+	Err  error
+}
+
+func NewImportService(repo store.Repository, cfg *config.Config, ts *TransactionService, rs *RuleService) *ImportService {
+	return &ImportService{repo: repo, config: cfg, transaction: ts, rule: rs}
+}
+
+// GetCSVProfile looks up a CSV profile from the config. Profile names are
+// case-insensitive because viper lowercases map keys.
+func (is *ImportService) GetCSVProfile(name string) (config.CSVProfile, error) {
+	for key, profile := range is.config.Import.Profiles {
+		if strings.EqualFold(key, name) {
+			return profile, nil
+		}
+	}
+	return config.CSVProfile{}, fmt.Errorf("import profile '%s' not found in config", name)
+}
+
+// ImportRecords creates one two-split transaction per record between
+// accountName and counterAccount. If counterAccount is empty, it is picked
+// per record by the categorization rules. Records whose external_id already
+// exists are skipped, so importing the same statement twice is harmless.
+func (is *ImportService) ImportRecords(accountName, counterAccount string, records []importer.Record) (*ImportResult, error) {
+	if _, err := is.repo.GetAccountByName(accountName); err != nil {
+		return nil, err
+	}
+
+	var matcher *RuleMatcher
+	if counterAccount == "" {
+		var err error
+		if matcher, err = is.rule.NewMatcher(); err != nil {
+			return nil, err
+		}
+	} else if _, err := is.repo.GetAccountByName(counterAccount); err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+
+	for _, record := range records {
+		counter := counterAccount
+		if matcher != nil {
+			var err error
+			counter, _, err = is.rule.CategorizeWith(matcher, record.Description, record.Amount, accountName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		input := TransactionInput{
+			Timestamp:   record.Timestamp,
+			Description: record.Description,
+			Status:      1,
+			ExternalID:  record.ExternalID,
+			Splits: []TransactionSplitInput{
+				{AccountName: accountName, Amount: record.Amount},
+				{AccountName: counter, Amount: -record.Amount},
+			},
+		}
+
+		_, err := is.transaction.CreateTransaction(input)
+		switch {
+		case err == nil:
+			result.Imported++
+		case errors.Is(err, store.ErrDuplicateExternalID):
+			result.Skipped++
+		default:
+			result.Failures = append(result.Failures, ImportFailure{Line: record.Line, Err: err})
+		}
+	}
+
+	return result, nil
+}
+
+// LedgerBalanceAsOf returns the balance of accountName including every
+// transaction dated on or before cutoff, for comparison with a statement.
+func (is *ImportService) LedgerBalanceAsOf(accountName string, cutoff int64) (int64, error) {
+	account, err := is.repo.GetAccountByName(accountName)
+	if err != nil {
+		return 0, err
+	}
+
+	balances, err := is.repo.GetBalancesAsOf(cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	return balances[account.ID][account.Currency], nil
+}
@@ -0,0 +1,475 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/store"
+	"github.com/hance08/kea/internal/utils"
+)
+
+type InvestmentService struct {
+	repo        store.Repository
+	config      *config.Config
+	transaction *TransactionService
+}
+
+func NewInvestmentService(repo store.Repository, cfg *config.Config, transaction *TransactionService) *InvestmentService {
+	return &InvestmentService{repo: repo, config: cfg, transaction: transaction}
+}
+
+// Trade is the result of buying or selling units of a commodity.
+type Trade struct {
+	TransactionID int64
+	Input         TransactionInput
+
+	Commodity string
+	Quantity  int64
+	Amount    int64 // total paid or received, in Currency
+	Currency  string
+
+	// CostBasis, Gain and Lots are only set for sales. Gain is negative
+	// for a loss.
+	CostBasis int64
+	Gain      int64
+	Lots      []LotMatch
+}
+
+// LotMatch is the part of a lot a sale is taken from.
+type LotMatch struct {
+	Lot      *model.Lot
+	Quantity int64
+	Cost     int64
+}
+
+// Holding is the open position of an investment account, with its market
+// value taken from the price table.
+type Holding struct {
+	Account      *model.Account
+	Lots         []*model.Lot
+	Quantity     int64
+	CostBasis    int64
+	CostCurrency string
+
+	// MarketValue is nil when no price is known for the commodity.
+	MarketValue *int64
+}
+
+// UnrealizedGain returns the market value minus the cost basis, or nil when
+// the market value is unknown.
+func (h *Holding) UnrealizedGain() *int64 {
+	if h.MarketValue == nil {
+		return nil
+	}
+	gain := *h.MarketValue - h.CostBasis
+	return &gain
+}
+
+// Buy records the purchase of quantity units of the commodity held by
+// holdingName at priceStr per unit, paid from cashName. The purchase opens
+// a new lot and its price is saved to the price table.
+func (is *InvestmentService) Buy(holdingName, cashName string, quantity int64, priceStr, desc string, timestamp int64) (*Trade, error) {
+	if quantity <= 0 {
+		return nil, invalid("quantity must be positive")
+	}
+
+	holding, cash, price, err := is.resolveTrade(holdingName, cashName, priceStr)
+	if err != nil {
+		return nil, err
+	}
+
+	cost := commodity.Convert(quantity, holding.Currency, cash.Currency, price)
+	if cost <= 0 {
+		return nil, invalid("total cost rounds to zero")
+	}
+
+	trade := &Trade{
+		Commodity: holding.Currency,
+		Quantity:  quantity,
+		Amount:    cost,
+		Currency:  cash.Currency,
+		Input: TransactionInput{
+			Timestamp:   timestamp,
+			Description: desc,
+			Status:      model.StatusCleared,
+			Splits: []TransactionSplitInput{
+				{
+					AccountName:  holding.Name,
+					AccountID:    holding.ID,
+					Amount:       quantity,
+					Currency:     holding.Currency,
+					CostAmount:   &cost,
+					CostCurrency: cash.Currency,
+				},
+				{
+					AccountName: cash.Name,
+					AccountID:   cash.ID,
+					Amount:      -cost,
+					Currency:    cash.Currency,
+				},
+			},
+		},
+	}
+
+	err = is.repo.ExecTx(func(repo store.Repository) error {
+		splitID, err := is.postTrade(repo, trade, holding, priceStr)
+		if err != nil {
+			return err
+		}
+
+		_, err = repo.CreateLot(model.Lot{
+			AccountID:    holding.ID,
+			SplitID:      splitID,
+			Date:         timestamp,
+			Quantity:     quantity,
+			CostAmount:   cost,
+			CostCurrency: cash.Currency,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return trade, nil
+}
+
+// Sell records the sale of quantity units held by holdingName at priceStr
+// per unit, paid into cashName. Units are taken from the given lots in
+// order, or from the oldest lots first (FIFO) when lotIDs is empty. The
+// difference between the proceeds and the cost basis of those units is
+// posted to gainName, or to Revenue:CapitalGains when gainName is empty.
+func (is *InvestmentService) Sell(holdingName, cashName, gainName string, quantity int64, priceStr string, lotIDs []int64, desc string, timestamp int64) (*Trade, error) {
+	if quantity <= 0 {
+		return nil, invalid("quantity must be positive")
+	}
+
+	holding, cash, price, err := is.resolveTrade(holdingName, cashName, priceStr)
+	if err != nil {
+		return nil, err
+	}
+
+	gainAccount, err := is.gainAccount(gainName, cash.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if gainAccount.Currency != cash.Currency {
+		return nil, invalid("gain account %s uses %s but the sale is paid in %s",
+			gainAccount.Name, gainAccount.Currency, cash.Currency)
+	}
+
+	open, err := is.repo.GetOpenLots(holding.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := matchLots(open, quantity, lotIDs, holding.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var basis int64
+	for _, m := range matches {
+		if m.Lot.CostCurrency != cash.Currency {
+			return nil, invalid("lot #%d was bought with %s and must be sold into a %s account",
+				m.Lot.ID, m.Lot.CostCurrency, m.Lot.CostCurrency)
+		}
+		basis += m.Cost
+	}
+
+	proceeds := commodity.Convert(quantity, holding.Currency, cash.Currency, price)
+	if proceeds <= 0 {
+		return nil, invalid("total proceeds round to zero")
+	}
+
+	// The holding leaves at cost, so the transaction balances once the
+	// gain is posted against the proceeds.
+	negBasis := -basis
+	splits := []TransactionSplitInput{
+		{
+			AccountName: cash.Name,
+			AccountID:   cash.ID,
+			Amount:      proceeds,
+			Currency:    cash.Currency,
+		},
+		{
+			AccountName:  holding.Name,
+			AccountID:    holding.ID,
+			Amount:       -quantity,
+			Currency:     holding.Currency,
+			CostAmount:   &negBasis,
+			CostCurrency: cash.Currency,
+		},
+	}
+
+	gain := proceeds - basis
+	if gain != 0 {
+		splits = append(splits, TransactionSplitInput{
+			AccountName: gainAccount.Name,
+			AccountID:   gainAccount.ID,
+			Amount:      -gain,
+			Currency:    gainAccount.Currency,
+		})
+	}
+
+	trade := &Trade{
+		Commodity: holding.Currency,
+		Quantity:  quantity,
+		Amount:    proceeds,
+		Currency:  cash.Currency,
+		CostBasis: basis,
+		Gain:      gain,
+		Lots:      matches,
+		Input: TransactionInput{
+			Timestamp:   timestamp,
+			Description: desc,
+			Status:      model.StatusCleared,
+			Splits:      splits,
+		},
+	}
+
+	err = is.repo.ExecTx(func(repo store.Repository) error {
+		splitID, err := is.postTrade(repo, trade, holding, priceStr)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range matches {
+			if _, err := repo.CreateLotDisposal(model.LotDisposal{
+				LotID:      m.Lot.ID,
+				SplitID:    splitID,
+				Quantity:   m.Quantity,
+				CostAmount: m.Cost,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return trade, nil
+}
+
+// GetHoldings returns the open positions of every investment account,
+// valued at the latest prices.
+func (is *InvestmentService) GetHoldings() ([]*Holding, error) {
+	return is.holdings(0)
+}
+
+// GetHolding returns the open positions of one investment account. An
+// account holding lots bought in several currencies has one position each.
+func (is *InvestmentService) GetHolding(accountName string) ([]*Holding, error) {
+	account, err := is.repo.GetAccountByName(accountName)
+	if err != nil {
+		return nil, err
+	}
+	return is.holdings(account.ID)
+}
+
+func (is *InvestmentService) holdings(accountID int64) ([]*Holding, error) {
+	lots, err := is.repo.GetOpenLots(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	type holdingKey struct {
+		AccountID int64
+		Currency  string
+	}
+
+	byKey := make(map[holdingKey]*Holding)
+	var holdings []*Holding
+
+	for _, lot := range lots {
+		key := holdingKey{AccountID: lot.AccountID, Currency: lot.CostCurrency}
+		h, ok := byKey[key]
+		if !ok {
+			account, err := is.repo.GetAccountByID(lot.AccountID)
+			if err != nil {
+				return nil, err
+			}
+			h = &Holding{Account: account, CostCurrency: lot.CostCurrency}
+			byKey[key] = h
+			holdings = append(holdings, h)
+		}
+
+		h.Lots = append(h.Lots, lot)
+		h.Quantity += lot.Remaining
+		h.CostBasis += lot.RemainingCost
+	}
+
+	now := time.Now().Unix()
+	converters := make(map[string]*Converter)
+
+	for _, h := range holdings {
+		converter, ok := converters[h.CostCurrency]
+		if !ok {
+			converter, err = newConverter(is.repo, h.CostCurrency)
+			if err != nil {
+				return nil, err
+			}
+			converters[h.CostCurrency] = converter
+		}
+
+		value, err := converter.Convert(h.Quantity, h.Account.Currency, now)
+		if err == nil {
+			h.MarketValue = &value
+		}
+	}
+
+	sort.SliceStable(holdings, func(i, j int) bool {
+		return holdings[i].Account.Name < holdings[j].Account.Name
+	})
+
+	return holdings, nil
+}
+
+// resolveTrade looks up the accounts of a trade and parses its unit price.
+// The holding account must be an asset held in the traded commodity, and
+// the cash account in the currency the price is quoted in.
+func (is *InvestmentService) resolveTrade(holdingName, cashName, priceStr string) (*model.Account, *model.Account, *big.Rat, error) {
+	holding, err := is.repo.GetAccountByName(holdingName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cash, err := is.repo.GetAccountByName(cashName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if holding.Type != "A" {
+		return nil, nil, nil, invalid("%s must be an asset account to hold investments", holding.Name)
+	}
+	if cash.Type != "A" && cash.Type != "L" {
+		return nil, nil, nil, invalid("%s must be an asset or liability account", cash.Name)
+	}
+	if holding.Currency == cash.Currency {
+		return nil, nil, nil, invalid("%s and %s both use %s, create the investment account with the ticker as its currency, e.g. --currency AAPL",
+			holding.Name, cash.Name, cash.Currency)
+	}
+
+	price, err := utils.ParseRate(priceStr)
+	if err != nil {
+		return nil, nil, nil, invalid("invalid price: %w", err)
+	}
+
+	return holding, cash, price, nil
+}
+
+// gainAccount returns the account realized gains in currency are posted to.
+// Without a name it is Revenue:CapitalGains, or its sub-account for currency
+// when that is not the default, created on first use.
+func (is *InvestmentService) gainAccount(name, currency string) (*model.Account, error) {
+	if name != "" {
+		account, err := is.repo.GetAccountByName(name)
+		if err != nil {
+			return nil, err
+		}
+		if account.Type != "R" {
+			return nil, invalid("gain account %s must be a revenue account", account.Name)
+		}
+		return account, nil
+	}
+
+	return ensureCurrencyAccount(is.repo, constants.SystemAccountCapitalGains, "R", "Realized investment gains and losses",
+		currency, is.config.Defaults.Currency)
+}
+
+// postTrade writes the trade transaction and saves the unit price it was
+// made at. It returns the ID of the split on the holding account.
+func (is *InvestmentService) postTrade(repo store.Repository, trade *Trade, holding *model.Account, priceStr string) (int64, error) {
+	splits := toModelSplits(trade.Input.Splits)
+	if err := is.transaction.ValidateSplitsBalance(splits); err != nil {
+		return 0, err
+	}
+
+	txID, err := repo.CreateTransactionWithSplits(model.Transaction{
+		Timestamp:   trade.Input.Timestamp,
+		Description: trade.Input.Description,
+		Status:      trade.Input.Status,
+	}, splits)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	trade.TransactionID = txID
+
+	price, err := newPrice(holding.Currency, trade.Currency, priceStr, trade.Input.Timestamp)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := repo.UpsertPrice(price); err != nil {
+		return 0, err
+	}
+
+	stored, err := repo.GetSplitsByTransaction(txID)
+	if err != nil {
+		return 0, err
+	}
+	for _, split := range stored {
+		if split.AccountID == holding.ID {
+			return split.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("split for %s not found in transaction #%d", holding.Name, txID)
+}
+
+// matchLots picks the lots a sale of quantity units is taken from: the
+// given lots in that order, or the oldest open lots first (FIFO). A lot
+// that is only partly sold gives up a proportional share of its cost.
+func matchLots(open []*model.Lot, quantity int64, lotIDs []int64, code string) ([]LotMatch, error) {
+	candidates := open
+	if len(lotIDs) > 0 {
+		byID := make(map[int64]*model.Lot, len(open))
+		for _, lot := range open {
+			byID[lot.ID] = lot
+		}
+
+		candidates = nil
+		seen := make(map[int64]bool, len(lotIDs))
+		for _, id := range lotIDs {
+			lot, ok := byID[id]
+			if !ok {
+				return nil, invalid("lot #%d is not an open lot of this account", id)
+			}
+			if seen[id] {
+				return nil, invalid("lot #%d is given more than once", id)
+			}
+			seen[id] = true
+			candidates = append(candidates, lot)
+		}
+	}
+
+	var matches []LotMatch
+	left := quantity
+
+	for _, lot := range candidates {
+		if left == 0 {
+			break
+		}
+
+		take := min(left, lot.Remaining)
+		cost := lot.RemainingCost
+		if take < lot.Remaining {
+			cost = utils.ApplyRate(lot.CostAmount, big.NewRat(take, lot.Quantity))
+		}
+
+		matches = append(matches, LotMatch{Lot: lot, Quantity: take, Cost: cost})
+		left -= take
+	}
+
+	if left > 0 {
+		return nil, invalid("not enough units to sell: %s short",
+			commodity.FormatWithCode(left, code))
+	}
+
+	return matches, nil
+}
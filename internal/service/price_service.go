@@ -0,0 +1,181 @@
+package service
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/importer"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/store"
+	"github.com/hance08/kea/internal/utils"
+)
+
+type PriceService struct {
+	repo   store.Repository
+	config *config.Config
+}
+
+func NewPriceService(repo store.Repository, cfg *config.Config) *PriceService {
+	return &PriceService{repo: repo, config: cfg}
+}
+
+// AddPrice records that one unit of base was worth rate units of quote on date.
+// An existing price for the same day and pair is replaced.
+func (ps *PriceService) AddPrice(base, quote, rateStr string, date int64) (*model.Price, error) {
+	price, err := newPrice(base, quote, rateStr, date)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := ps.repo.UpsertPrice(price)
+	if err != nil {
+		return nil, err
+	}
+	price.ID = id
+
+	return &price, nil
+}
+
+func newPrice(base, quote, rateStr string, date int64) (model.Price, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+
+	if base == "" || quote == "" {
+		return model.Price{}, invalid("base and quote commodities are required")
+	}
+	if base == quote {
+		return model.Price{}, invalid("base and quote commodities must differ")
+	}
+
+	if _, err := utils.ParseRate(rateStr); err != nil {
+		return model.Price{}, err
+	}
+
+	return model.Price{
+		Date:  date,
+		Base:  base,
+		Quote: quote,
+		Rate:  strings.TrimSpace(rateStr),
+	}, nil
+}
+
+// ListPrices returns prices newest first, optionally limited to one commodity.
+func (ps *PriceService) ListPrices(commodity string) ([]*model.Price, error) {
+	return ps.repo.GetPrices(strings.ToUpper(strings.TrimSpace(commodity)))
+}
+
+// ImportPrices stores every record, collecting per-line failures instead of
+// aborting the whole file.
+func (ps *PriceService) ImportPrices(records []importer.PriceRecord) (*ImportResult, error) {
+	result := &ImportResult{}
+
+	err := ps.repo.ExecTx(func(repo store.Repository) error {
+		for _, rec := range records {
+			price, err := newPrice(rec.Base, rec.Quote, rec.Rate, rec.Date)
+			if err != nil {
+				result.Failures = append(result.Failures, ImportFailure{Line: rec.Line, Err: err})
+				continue
+			}
+			if _, err := repo.UpsertPrice(price); err != nil {
+				return err
+			}
+			result.Imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// NewConverter loads the price history for converting amounts into the
+// default currency.
+func (ps *PriceService) NewConverter() (*Converter, error) {
+	return newConverter(ps.repo, ps.config.Defaults.Currency)
+}
+
+type pricePair struct {
+	Base  string
+	Quote string
+}
+
+// Converter converts amounts into a target currency using the most recent
+// price on or before a given date. Both directions of a pair are used, so a
+// EUR/USD price also converts USD into EUR.
+type Converter struct {
+	target  string
+	history map[pricePair][]*model.Price // newest first
+}
+
+func newConverter(repo store.PriceRepository, target string) (*Converter, error) {
+	prices, err := repo.GetPrices("")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Converter{
+		target:  target,
+		history: make(map[pricePair][]*model.Price),
+	}
+	for _, p := range prices {
+		pair := pricePair{Base: p.Base, Quote: p.Quote}
+		c.history[pair] = append(c.history[pair], p)
+	}
+
+	return c, nil
+}
+
+// Target returns the currency amounts are converted into.
+func (c *Converter) Target() string {
+	return c.target
+}
+
+// Rate returns the number of target units per one unit of from, as of asOf.
+func (c *Converter) Rate(from string, asOf int64) (*big.Rat, error) {
+	if from == c.target || from == "" {
+		return big.NewRat(1, 1), nil
+	}
+
+	direct := latestPrice(c.history[pricePair{Base: from, Quote: c.target}], asOf)
+	inverse := latestPrice(c.history[pricePair{Base: c.target, Quote: from}], asOf)
+
+	if direct != nil && (inverse == nil || direct.Date >= inverse.Date) {
+		return utils.ParseRate(direct.Rate)
+	}
+
+	if inverse != nil {
+		rate, err := utils.ParseRate(inverse.Rate)
+		if err != nil {
+			return nil, err
+		}
+		return rate.Inv(rate), nil
+	}
+
+	return nil, invalid("no %s/%s price on or before %s", from, c.target, utils.FormatDate(asOf))
+}
+
+// Convert converts an amount in cents of from into the target currency.
+func (c *Converter) Convert(amount int64, from string, asOf int64) (int64, error) {
+	if from == c.target || from == "" || amount == 0 {
+		return amount, nil
+	}
+
+	rate, err := c.Rate(from, asOf)
+	if err != nil {
+		return 0, err
+	}
+	return commodity.Convert(amount, from, c.target, rate), nil
+}
+
+func latestPrice(history []*model.Price, asOf int64) *model.Price {
+	for _, p := range history {
+		if p.Date <= asOf {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/store"
+)
+
+type ReconcileService struct {
+	repo   store.Repository
+	config *config.Config
+}
+
+// ReconcileSession holds what is needed to reconcile an account against a
+// statement. Amounts are in stored sign; use NaturalSign for display.
+type ReconcileSession struct {
+	Account           *model.Account
+	StatementDate     int64
+	StatementBalance  int64
+	ReconciledBalance int64 // balance of previously reconciled splits
+	Entries           []*model.AccountEntry
+}
+
+func NewReconcileService(repo store.Repository, cfg *config.Config) *ReconcileService {
+	return &ReconcileService{repo: repo, config: cfg}
+}
+
+// StartSession loads the pending and cleared entries of the account up to
+// the statement date. statementBalance is given in the account's natural sign.
+func (rs *ReconcileService) StartSession(accountName string, statementDate, statementBalance int64) (*ReconcileSession, error) {
+	account, err := rs.repo.GetAccountByName(accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := rs.repo.GetUnreconciledEntries(account.ID, statementDate)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciled, err := rs.repo.GetReconciledBalance(account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReconcileSession{
+		Account:           account,
+		StatementDate:     statementDate,
+		StatementBalance:  statementBalance * NaturalSign(account.Type),
+		ReconciledBalance: reconciled,
+		Entries:           entries,
+	}, nil
+}
+
+// Difference returns statement balance minus the reconciled balance plus the
+// selected entries, in stored sign. Zero means the selection matches.
+func (s *ReconcileSession) Difference(selected map[int64]bool) int64 {
+	total := s.ReconciledBalance
+	for _, entry := range s.Entries {
+		if selected[entry.SplitID] {
+			total += entry.Amount
+		}
+	}
+	return s.StatementBalance - total
+}
+
+// Complete marks the transactions of the selected entries reconciled and
+// records the session, atomically. It refuses if the difference is not zero.
+func (rs *ReconcileService) Complete(session *ReconcileSession, selected map[int64]bool) error {
+	if diff := session.Difference(selected); diff != 0 {
+		return fmt.Errorf("cannot reconcile: difference is %s, must be 0", commodity.FormatWithCode(diff*NaturalSign(session.Account.Type), session.Account.Currency))
+	}
+
+	txIDs := make(map[int64]bool)
+	for _, entry := range session.Entries {
+		if selected[entry.SplitID] {
+			txIDs[entry.TransactionID] = true
+		}
+	}
+
+	return rs.repo.ExecTx(func(repo store.Repository) error {
+		for txID := range txIDs {
+			if err := repo.UpdateTransactionStatus(txID, model.StatusReconciled); err != nil {
+				return err
+			}
+		}
+
+		_, err := repo.CreateReconciliation(model.Reconciliation{
+			AccountID:     session.Account.ID,
+			StatementDate: session.StatementDate,
+			EndingBalance: session.StatementBalance,
+			CreatedAt:     time.Now().Unix(),
+		})
+		return err
+	})
+}
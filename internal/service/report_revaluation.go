@@ -0,0 +1,163 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/utils"
+)
+
+// GetRevaluation compares the book value of every asset and liability held
+// in a foreign currency, i.e. what its balance cost in the default currency
+// at the rates it was booked at, with its value at the closing rate on
+// cutoff. Investment accounts are left to the holdings report.
+//
+// Splits carrying a cost in the default currency are booked at that cost,
+// others at the rate on their transaction date. Earlier revaluations are
+// part of the book value, so posting the result again yields no gain.
+func (rs *ReportService) GetRevaluation(cutoff int64) (*Revaluation, error) {
+	accounts, err := rs.repo.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	lots, err := rs.repo.GetOpenLots(0)
+	if err != nil {
+		return nil, err
+	}
+	investments := make(map[int64]bool)
+	for _, lot := range lots {
+		investments[lot.AccountID] = true
+	}
+
+	converter, err := newConverter(rs.repo, rs.config.Defaults.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	rev := &Revaluation{
+		Date:     cutoff,
+		Currency: converter.Target(),
+	}
+
+	for _, acc := range accounts {
+		if acc.Type != "A" && acc.Type != "L" {
+			continue
+		}
+		if acc.Currency == converter.Target() || investments[acc.ID] {
+			continue
+		}
+
+		entries, err := rs.repo.GetAccountEntriesAsOf(acc.ID, cutoff)
+		if err != nil {
+			return nil, err
+		}
+
+		line := RevaluationLine{Account: acc}
+		for _, entry := range entries {
+			cost, err := bookValue(converter, entry)
+			if err != nil {
+				return nil, fmt.Errorf("cannot find the book value of %s: %w", acc.Name, err)
+			}
+			line.Balance += entry.Amount
+			line.BookValue += cost
+		}
+
+		if line.Balance == 0 && line.BookValue == 0 {
+			continue
+		}
+
+		line.Rate, err = converter.Rate(acc.Currency, cutoff)
+		if err != nil {
+			return nil, fmt.Errorf("cannot revalue %s: %w", acc.Name, err)
+		}
+		line.Value, err = converter.Convert(line.Balance, acc.Currency, cutoff)
+		if err != nil {
+			return nil, fmt.Errorf("cannot revalue %s: %w", acc.Name, err)
+		}
+		line.Gain = line.Value - line.BookValue
+
+		rev.Lines = append(rev.Lines, line)
+		rev.Total += line.Gain
+	}
+
+	return rev, nil
+}
+
+// PostRevaluation books the gains of rev against accountName, or against
+// Revenue:FX when it is empty, in a transaction dated timestamp. The foreign
+// accounts get a split with no amount whose cost is the gain, so their
+// balances stay the same while their book value moves to the closing rate.
+func (rs *ReportService) PostRevaluation(rev *Revaluation, accountName string, timestamp int64) (int64, error) {
+	target, err := rs.revaluationAccount(accountName, rev.Currency)
+	if err != nil {
+		return 0, err
+	}
+
+	var splits []TransactionSplitInput
+	for _, line := range rev.Lines {
+		if line.Gain == 0 {
+			continue
+		}
+		gain := line.Gain
+		splits = append(splits, TransactionSplitInput{
+			AccountName:  line.Account.Name,
+			Amount:       0,
+			CostAmount:   &gain,
+			CostCurrency: rev.Currency,
+			Memo:         fmt.Sprintf("Revaluation at %s %s", utils.FormatRate(line.Rate), rev.Currency),
+		})
+	}
+
+	if len(splits) == 0 {
+		return 0, fmt.Errorf("nothing to revalue: every foreign balance is already booked at the closing rate")
+	}
+
+	if rev.Total != 0 {
+		splits = append(splits, TransactionSplitInput{
+			AccountName: target.Name,
+			Amount:      -rev.Total,
+		})
+	}
+
+	return rs.transaction.CreateTransaction(TransactionInput{
+		Timestamp:   timestamp,
+		Description: "Currency revaluation",
+		Status:      model.StatusCleared,
+		Splits:      splits,
+	})
+}
+
+// revaluationAccount returns the equity or revenue account revaluation
+// gains are posted to, creating the default Revenue:FX account on first use.
+func (rs *ReportService) revaluationAccount(name, currency string) (*model.Account, error) {
+	if name == "" {
+		name = constants.SystemAccountFX
+
+		if err := ensureSystemAccount(rs.repo, name, "R", currency, "Currency revaluation gains and losses", nil); err != nil {
+			return nil, err
+		}
+	}
+
+	account, err := rs.repo.GetAccountByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if account.Type != "C" && account.Type != "R" {
+		return nil, fmt.Errorf("revaluation account %s must be an equity or revenue account", account.Name)
+	}
+	if account.Currency != currency {
+		return nil, fmt.Errorf("revaluation account %s must use %s, not %s", account.Name, currency, account.Currency)
+	}
+	return account, nil
+}
+
+// bookValue returns what a split was booked at in the converter's target:
+// its cost when it has one, otherwise its amount at the rate on its date.
+func bookValue(converter *Converter, entry *model.AccountEntry) (int64, error) {
+	if entry.CostAmount != nil {
+		return converter.Convert(*entry.CostAmount, entry.CostCurrency, entry.Timestamp)
+	}
+	return converter.Convert(entry.Amount, entry.Currency, entry.Timestamp)
+}
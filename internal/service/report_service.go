@@ -0,0 +1,269 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/store"
+)
+
+type ReportService struct {
+	repo        store.Repository
+	config      *config.Config
+	transaction *TransactionService
+}
+
+func NewReportService(repo store.Repository, cfg *config.Config, transaction *TransactionService) *ReportService {
+	return &ReportService{repo: repo, config: cfg, transaction: transaction}
+}
+
+// NaturalSign returns the multiplier that turns a stored amount into its
+// natural presentation. Debit-normal accounts (A, E) keep their sign, while
+// credit-normal accounts (L, C, R) are stored negative and get flipped.
+func NaturalSign(accType string) int64 {
+	switch accType {
+	case "L", "C", "R":
+		return -1
+	default:
+		return 1
+	}
+}
+
+// GetBalanceSheet builds the balance sheet as of cutoff (inclusive).
+// Child balances are rolled up into their parents via parent_id, and
+// balances in other currencies are converted at the rate on cutoff.
+func (rs *ReportService) GetBalanceSheet(cutoff int64) (*BalanceSheet, error) {
+	accounts, err := rs.repo.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	raw, err := rs.repo.GetBalancesAsOf(cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := newConverter(rs.repo, rs.config.Defaults.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, conversions, err := convertBalances(converter, accounts, raw, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &BalanceSheet{
+		Date:        cutoff,
+		Currency:    converter.Target(),
+		Assets:      buildSection("Assets", "A", accounts, balances),
+		Liabilities: buildSection("Liabilities", "L", accounts, balances),
+		Equity:      buildSection("Equity", "C", accounts, balances),
+	}
+
+	var revenue, expenses int64
+	for _, acc := range accounts {
+		switch acc.Type {
+		case "R":
+			revenue += -balances[acc.ID]
+		case "E":
+			expenses += balances[acc.ID]
+		}
+	}
+
+	translation, translated, err := rs.translation(converter, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet.CurrentEarnings = revenue - expenses
+	sheet.Translation = translation
+	sheet.Difference = sheet.Assets.Total -
+		(sheet.Liabilities.Total + sheet.Equity.Total + sheet.CurrentEarnings + sheet.Translation)
+
+	// Each conversion rounds to the minor unit, so the converted figures can
+	// be off by half a unit per converted amount without the ledger being
+	// out of balance.
+	if diff := sheet.Difference; diff != 0 && 2*max(diff, -diff) <= int64(conversions+translated) {
+		sheet.Translation += diff
+		sheet.Difference = 0
+	}
+
+	return sheet, nil
+}
+
+// GetIncomeStatement totals Revenue and Expenses within [startTime, endTime].
+// Revenue is presented positive, so NetIncome = Revenue - Expenses.
+func (rs *ReportService) GetIncomeStatement(startTime, endTime int64) (*IncomeStatement, error) {
+	if startTime > endTime {
+		return nil, invalid("start date must be before end date")
+	}
+
+	accounts, err := rs.repo.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	raw, err := rs.repo.GetAccountTotalsByDateRange(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := newConverter(rs.repo, rs.config.Defaults.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, _, err := convertBalances(converter, accounts, raw, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &IncomeStatement{
+		From:     startTime,
+		To:       endTime,
+		Currency: converter.Target(),
+		Revenue:  buildSection("Revenue", "R", accounts, totals),
+		Expenses: buildSection("Expenses", "E", accounts, totals),
+	}
+	statement.NetIncome = statement.Revenue.Total - statement.Expenses.Total
+
+	return statement, nil
+}
+
+// convertBalances converts per-account balances, held in one or more
+// currencies, into the converter's target using the rates on asOf. It also
+// returns how many foreign amounts were converted.
+func convertBalances(converter *Converter, accounts []*model.Account, balances map[int64]map[string]int64, asOf int64) (map[int64]int64, int, error) {
+	converted := make(map[int64]int64, len(balances))
+	conversions := 0
+
+	for _, acc := range accounts {
+		amounts, ok := balances[acc.ID]
+		if !ok {
+			continue
+		}
+
+		total, n, err := convertAmounts(converter, amounts, asOf)
+		if err != nil {
+			return nil, 0, fmt.Errorf("cannot convert %s: %w", acc.Name, err)
+		}
+		converted[acc.ID] = total
+		conversions += n
+	}
+
+	return converted, conversions, nil
+}
+
+// convertAmounts converts amounts keyed by currency into the converter's
+// target and sums them. It also returns how many foreign amounts were
+// converted.
+func convertAmounts(converter *Converter, amounts map[string]int64, asOf int64) (int64, int, error) {
+	var total int64
+	conversions := 0
+	for currency, amount := range amounts {
+		value, err := converter.Convert(amount, currency, asOf)
+		if err != nil {
+			return 0, 0, err
+		}
+		if amount != 0 && currency != converter.Target() {
+			conversions++
+		}
+		total += value
+	}
+	return total, conversions, nil
+}
+
+// translation returns the currency translation adjustment as of cutoff: what
+// the splits booked at a cost in another currency are worth at the rates on
+// cutoff, less that cost. Splits without a cost balance within their own
+// currency, so these are the only ones whose converted value can leave the
+// sheet unbalanced. It also returns how many foreign amounts were converted.
+func (rs *ReportService) translation(converter *Converter, cutoff int64) (int64, int, error) {
+	totals, err := rs.repo.GetCostTotalsAsOf(cutoff)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var adjustment int64
+	conversions := 0
+	for _, total := range totals {
+		value, n, err := convertAmounts(converter, map[string]int64{total.Currency: total.Amount}, cutoff)
+		if err != nil {
+			return 0, 0, fmt.Errorf("cannot convert %s: %w", total.Currency, err)
+		}
+		cost, m, err := convertAmounts(converter, map[string]int64{total.CostCurrency: total.CostAmount}, cutoff)
+		if err != nil {
+			return 0, 0, fmt.Errorf("cannot convert %s: %w", total.CostCurrency, err)
+		}
+		adjustment += value - cost
+		conversions += n + m
+	}
+
+	return adjustment, conversions, nil
+}
+
+// buildSection builds the account trees for a single type and totals its roots.
+func buildSection(title, accType string, accounts []*model.Account, balances map[int64]int64) ReportSection {
+	var filtered []*model.Account
+	for _, acc := range accounts {
+		if acc.Type == accType {
+			filtered = append(filtered, acc)
+		}
+	}
+
+	section := ReportSection{
+		Title: title,
+		Type:  accType,
+		Roots: BuildAccountTree(filtered, balances),
+	}
+	for _, root := range section.Roots {
+		section.Total += root.Total
+	}
+	return section
+}
+
+// BuildAccountTree links accounts into a forest using parent_id and rolls
+// every node's balance up into its ancestors. Accounts whose parent is not in
+// the given slice are treated as roots. Siblings are sorted by name.
+func BuildAccountTree(accounts []*model.Account, balances map[int64]int64) []*AccountNode {
+	nodes := make(map[int64]*AccountNode, len(accounts))
+	for _, acc := range accounts {
+		nodes[acc.ID] = &AccountNode{
+			Account: acc,
+			Balance: balances[acc.ID] * NaturalSign(acc.Type),
+		}
+	}
+
+	var roots []*AccountNode
+	for _, acc := range accounts {
+		node := nodes[acc.ID]
+		if acc.ParentID != nil {
+			if parent, ok := nodes[*acc.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	var rollUp func(list []*AccountNode, depth int)
+	rollUp = func(list []*AccountNode, depth int) {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Account.Name < list[j].Account.Name
+		})
+		for _, n := range list {
+			n.Depth = depth
+			rollUp(n.Children, depth+1)
+			n.Total = n.Balance
+			for _, child := range n.Children {
+				n.Total += child.Total
+			}
+		}
+	}
+	rollUp(roots, 0)
+
+	return roots
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hance08/kea/internal/model"
+)
+
+// GetTrend builds a matrix of per-account totals for accType, bucketed by
+// period (month, quarter or year) between startTime and endTime. Every
+// period in the range gets a column, even if it has no activity.
+func (rs *ReportService) GetTrend(accType, period string, startTime, endTime int64) (*TrendReport, error) {
+	if startTime > endTime {
+		return nil, invalid("start date must be before end date")
+	}
+
+	periods, err := periodLabels(period, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	columnIndex := make(map[string]int, len(periods))
+	for i, label := range periods {
+		columnIndex[label] = i
+	}
+
+	monthly, err := rs.repo.GetMonthlyTotalsByType(accType, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := newConverter(rs.repo, rs.config.Defaults.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	sign := NaturalSign(accType)
+	rowsByAccount := make(map[int64]*TrendRow)
+	accounts := make(map[int64]*model.Account)
+
+	for _, mt := range monthly {
+		monthStart, err := time.Parse("2006-01", mt.Month)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected month format '%s': %w", mt.Month, err)
+		}
+
+		col, ok := columnIndex[periodLabel(period, monthStart)]
+		if !ok {
+			continue
+		}
+
+		account, ok := accounts[mt.AccountID]
+		if !ok {
+			if account, err = rs.repo.GetAccountByID(mt.AccountID); err != nil {
+				return nil, err
+			}
+			accounts[mt.AccountID] = account
+		}
+
+		// Convert each month at its closing rate.
+		asOf := min(monthStart.AddDate(0, 1, 0).Unix()-1, endTime)
+		amount, err := converter.Convert(mt.Amount, account.Currency, asOf)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %s: %w", account.Name, err)
+		}
+
+		row, ok := rowsByAccount[mt.AccountID]
+		if !ok {
+			row = &TrendRow{
+				AccountName: account.Name,
+				Amounts:     make([]int64, len(periods)),
+			}
+			rowsByAccount[mt.AccountID] = row
+		}
+
+		row.Amounts[col] += amount * sign
+	}
+
+	report := &TrendReport{
+		Type:         accType,
+		Period:       period,
+		Currency:     rs.config.Defaults.Currency,
+		Periods:      periods,
+		ColumnTotals: make([]int64, len(periods)),
+	}
+
+	for _, row := range rowsByAccount {
+		for i, amount := range row.Amounts {
+			row.Total += amount
+			report.ColumnTotals[i] += amount
+		}
+		row.Average = row.Total / int64(len(periods))
+		report.GrandTotal += row.Total
+		report.Rows = append(report.Rows, *row)
+	}
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		return report.Rows[i].AccountName < report.Rows[j].AccountName
+	})
+	report.Average = report.GrandTotal / int64(len(periods))
+
+	return report, nil
+}
+
+// periodLabels lists the labels of every period touching [startTime, endTime].
+func periodLabels(period string, startTime, endTime int64) ([]string, error) {
+	start := time.Unix(startTime, 0).UTC()
+	end := time.Unix(endTime, 0).UTC()
+
+	var step int
+	switch period {
+	case PeriodMonth:
+		step = 1
+	case PeriodQuarter:
+		step = 3
+	case PeriodYear:
+		step = 12
+	default:
+		return nil, invalid("invalid period '%s' (must be month, quarter or year)", period)
+	}
+
+	// Align the cursor to the first month of the period containing start.
+	month := int(start.Month()) - 1
+	month -= month % step
+	cursor := time.Date(start.Year(), time.Month(month+1), 1, 0, 0, 0, 0, time.UTC)
+
+	var labels []string
+	for !cursor.After(end) {
+		labels = append(labels, periodLabel(period, cursor))
+		cursor = cursor.AddDate(0, step, 0)
+	}
+
+	return labels, nil
+}
+
+func periodLabel(period string, t time.Time) string {
+	switch period {
+	case PeriodQuarter:
+		return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	case PeriodYear:
+		return fmt.Sprintf("%d", t.Year())
+	default:
+		return t.Format("2006-01")
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"math/big"
+
+	"github.com/hance08/kea/internal/model"
+)
+
+// AccountNode is one account in the hierarchy with its balances.
+// Amounts are presented with natural signs (see NaturalSign).
+type AccountNode struct {
+	Account  *model.Account
+	Depth    int
+	Balance  int64 // own splits only
+	Total    int64 // own splits plus all descendants
+	Children []*AccountNode
+}
+
+// ReportSection groups the account trees of a single account type.
+type ReportSection struct {
+	Title string
+	Type  string
+	Roots []*AccountNode
+	Total int64
+}
+
+// BalanceSheet is the financial position as of a cutoff date.
+type BalanceSheet struct {
+	Date        int64
+	Currency    string
+	Assets      ReportSection
+	Liabilities ReportSection
+	Equity      ReportSection
+
+	// CurrentEarnings is Revenue minus Expenses not yet closed into equity.
+	CurrentEarnings int64
+
+	// Translation is the change in value of foreign-currency balances from
+	// converting them at the report date instead of the rates they were booked at.
+	Translation int64
+
+	// Difference is A - (L + C + (R - E) + Translation); non-zero means the
+	// ledger is out of balance.
+	Difference int64
+}
+
+// IncomeStatement is the profit and loss over a date range.
+type IncomeStatement struct {
+	From      int64
+	To        int64
+	Currency  string
+	Revenue   ReportSection
+	Expenses  ReportSection
+	NetIncome int64
+}
+
+const (
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter"
+	PeriodYear    = "year"
+)
+
+// TrendReport is an account x period matrix of totals for one account type.
+type TrendReport struct {
+	Type         string
+	Period       string
+	Currency     string
+	Periods      []string
+	Rows         []TrendRow
+	ColumnTotals []int64
+	GrandTotal   int64
+	Average      int64
+}
+
+// TrendRow holds one account's totals per period, aligned with TrendReport.Periods.
+type TrendRow struct {
+	AccountName string
+	Amounts     []int64
+	Total       int64
+	Average     int64
+}
+
+// Flatten returns the nodes of the section in depth-first order.
+func (rs ReportSection) Flatten() []*AccountNode {
+	var nodes []*AccountNode
+	var walk func([]*AccountNode)
+	walk = func(list []*AccountNode) {
+		for _, n := range list {
+			nodes = append(nodes, n)
+			walk(n.Children)
+		}
+	}
+	walk(rs.Roots)
+	return nodes
+}
+
+// Revaluation lists the unrealized exchange gains and losses of foreign
+// currency accounts as of a date, in the default currency.
+type Revaluation struct {
+	Date     int64
+	Currency string
+	Lines    []RevaluationLine
+	Total    int64
+}
+
+// RevaluationLine is one foreign-currency account. Amounts use stored signs,
+// so a positive Gain is a gain for assets and liabilities alike.
+type RevaluationLine struct {
+	Account   *model.Account
+	Balance   int64    // in the account's currency
+	BookValue int64    // at the rates the splits were booked at
+	Rate      *big.Rat // closing rate
+	Value     int64    // at the closing rate
+	Gain      int64    // Value - BookValue
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/store"
+	"github.com/hance08/kea/internal/utils"
+)
+
+type RuleService struct {
+	repo   store.Repository
+	config *config.Config
+}
+
+// RuleInput is a rule as entered by the user, with account names instead of IDs.
+type RuleInput struct {
+	Priority           int
+	DescriptionPattern string
+	MinAmount          *int64
+	MaxAmount          *int64
+	SourceAccount      string
+	TargetAccount      string
+}
+
+// RuleDetail is a stored rule with its accounts resolved to names.
+type RuleDetail struct {
+	ID                 int64
+	Priority           int
+	DescriptionPattern string
+	MinAmount          *int64
+	MaxAmount          *int64
+	SourceAccount      string
+	TargetAccount      string
+	Currency           string // currency of the amount range
+
+	pattern *regexp.Regexp
+}
+
+// RuleMatcher evaluates a preloaded, compiled rule set. Build one per batch
+// (e.g. per import) instead of hitting the database for every row.
+type RuleMatcher struct {
+	rules []*RuleDetail
+}
+
+func NewRuleService(repo store.Repository, cfg *config.Config) *RuleService {
+	return &RuleService{repo: repo, config: cfg}
+}
+
+// AddRule validates and stores a new rule. The target must be an Expenses or
+// Revenue account; description patterns are matched case-insensitively.
+func (rs *RuleService) AddRule(input RuleInput) (int64, error) {
+	if input.DescriptionPattern == "" && input.MinAmount == nil && input.MaxAmount == nil && input.SourceAccount == "" {
+		return 0, fmt.Errorf("rule needs at least one condition (description pattern, amount range or source account)")
+	}
+
+	if _, err := compileRulePattern(input.DescriptionPattern); err != nil {
+		return 0, err
+	}
+
+	if input.MinAmount != nil && input.MaxAmount != nil && *input.MinAmount > *input.MaxAmount {
+		return 0, fmt.Errorf("minimum amount cannot be greater than maximum amount")
+	}
+
+	target, err := rs.repo.GetAccountByName(input.TargetAccount)
+	if err != nil {
+		return 0, err
+	}
+	if target.Type != "E" && target.Type != "R" {
+		return 0, fmt.Errorf("rule target must be an Expenses (E) or Revenue (R) account, '%s' is type %s", target.Name, target.Type)
+	}
+
+	rule := model.Rule{
+		Priority:           input.Priority,
+		DescriptionPattern: input.DescriptionPattern,
+		MinAmount:          input.MinAmount,
+		MaxAmount:          input.MaxAmount,
+		TargetAccountID:    target.ID,
+	}
+
+	if input.SourceAccount != "" {
+		source, err := rs.repo.GetAccountByName(input.SourceAccount)
+		if err != nil {
+			return 0, err
+		}
+		rule.SourceAccountID = &source.ID
+	}
+
+	return rs.repo.CreateRule(rule)
+}
+
+// AmountCurrency returns the currency a rule's amount range is expressed in:
+// the source account's currency, or the default currency without one.
+func (rs *RuleService) AmountCurrency(sourceAccount string) (string, error) {
+	if sourceAccount == "" {
+		return rs.config.Defaults.Currency, nil
+	}
+
+	account, err := rs.repo.GetAccountByName(sourceAccount)
+	if err != nil {
+		return "", err
+	}
+	return account.Currency, nil
+}
+
+// ListRules returns all rules in evaluation order.
+func (rs *RuleService) ListRules() ([]*RuleDetail, error) {
+	rules, err := rs.repo.GetAllRules()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make(map[int64]*model.Account)
+	accountOf := func(id int64) (*model.Account, error) {
+		if account, ok := accounts[id]; ok {
+			return account, nil
+		}
+		account, err := rs.repo.GetAccountByID(id)
+		if err != nil {
+			return nil, err
+		}
+		accounts[id] = account
+		return account, nil
+	}
+
+	details := make([]*RuleDetail, 0, len(rules))
+	for _, rule := range rules {
+		detail := &RuleDetail{
+			ID:                 rule.ID,
+			Priority:           rule.Priority,
+			DescriptionPattern: rule.DescriptionPattern,
+			MinAmount:          rule.MinAmount,
+			MaxAmount:          rule.MaxAmount,
+			Currency:           rs.config.Defaults.Currency,
+		}
+
+		target, err := accountOf(rule.TargetAccountID)
+		if err != nil {
+			return nil, err
+		}
+		detail.TargetAccount = target.Name
+
+		if rule.SourceAccountID != nil {
+			source, err := accountOf(*rule.SourceAccountID)
+			if err != nil {
+				return nil, err
+			}
+			detail.SourceAccount = source.Name
+			detail.Currency = source.Currency
+		}
+
+		details = append(details, detail)
+	}
+
+	return details, nil
+}
+
+func (rs *RuleService) DeleteRule(ruleID int64) error {
+	return rs.repo.DeleteRule(ruleID)
+}
+
+// NewMatcher loads and compiles all rules.
+func (rs *RuleService) NewMatcher() (*RuleMatcher, error) {
+	rules, err := rs.ListRules()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		rule.pattern, err = compileRulePattern(rule.DescriptionPattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule #%d: %w", rule.ID, err)
+		}
+	}
+
+	return &RuleMatcher{rules: rules}, nil
+}
+
+// Categorize returns the counter account for a transaction. amount is
+// compared by absolute value. When no rule matches, it falls back to the
+// uncategorized account in the source account's currency, creating that
+// account on first use.
+func (rs *RuleService) Categorize(description string, amount int64, sourceAccount string) (string, *RuleDetail, error) {
+	matcher, err := rs.NewMatcher()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return rs.CategorizeWith(matcher, description, amount, sourceAccount)
+}
+
+// CategorizeWith is like Categorize but reuses a preloaded matcher.
+func (rs *RuleService) CategorizeWith(matcher *RuleMatcher, description string, amount int64, sourceAccount string) (string, *RuleDetail, error) {
+	if rule := matcher.Match(description, amount, sourceAccount); rule != nil {
+		return rule.TargetAccount, rule, nil
+	}
+
+	account, err := rs.uncategorizedAccount(sourceAccount)
+	if err != nil {
+		return "", nil, err
+	}
+	return account, nil, nil
+}
+
+// Match returns the first rule whose conditions all hold, or nil.
+func (m *RuleMatcher) Match(description string, amount int64, sourceAccount string) *RuleDetail {
+	absAmount := utils.AbsInt64(amount)
+
+	for _, rule := range m.rules {
+		if rule.pattern != nil && !rule.pattern.MatchString(description) {
+			continue
+		}
+		if rule.MinAmount != nil && absAmount < *rule.MinAmount {
+			continue
+		}
+		if rule.MaxAmount != nil && absAmount > *rule.MaxAmount {
+			continue
+		}
+		if rule.SourceAccount != "" && rule.SourceAccount != sourceAccount {
+			continue
+		}
+		return rule
+	}
+
+	return nil
+}
+
+// uncategorizedAccount returns the fallback counter account for
+// sourceAccount, in the source account's currency.
+func (rs *RuleService) uncategorizedAccount(sourceAccount string) (string, error) {
+	source, err := rs.repo.GetAccountByName(sourceAccount)
+	if err != nil {
+		return "", err
+	}
+
+	account, err := ensureCurrencyAccount(rs.repo, constants.SystemAccountUncategorized, "E", "Uncategorized",
+		source.Currency, rs.config.Defaults.Currency)
+	if err != nil {
+		return "", err
+	}
+	return account.Name, nil
+}
+
+// ensureCurrencyAccount returns the system account name in currency,
+// creating it on first use. name itself is in the default currency; other
+// currencies get a sub-account of it, e.g. Expenses:Uncategorized:EUR, so
+// transactions posted to it balance.
+func ensureCurrencyAccount(repo store.Repository, name, accType, description, currency, defaultCurrency string) (*model.Account, error) {
+	if err := ensureSystemAccount(repo, name, accType, defaultCurrency, description, nil); err != nil {
+		return nil, err
+	}
+	parent, err := repo.GetAccountByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if currency == defaultCurrency {
+		return parent, nil
+	}
+
+	name += ":" + currency
+	if err := ensureSystemAccount(repo, name, accType, currency, description+" "+currency, &parent.ID); err != nil {
+		return nil, err
+	}
+	return repo.GetAccountByName(name)
+}
+
+// ensureSystemAccount creates the system account name on first use. System
+// accounts directly under a root have no parent, like every top-level account.
+func ensureSystemAccount(repo store.Repository, name, accType, currency, description string, parentID *int64) error {
+	exists, err := repo.AccountExists(name)
+	if err != nil || exists {
+		return err
+	}
+
+	_, err = repo.CreateAccount(name, accType, currency, description+" (System Account)", parentID)
+	if err != nil {
+		return fmt.Errorf("failed to create '%s' account: %w", name, err)
+	}
+	return nil
+}
+
+func compileRulePattern(pattern string) (*regexp.Regexp, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid description pattern '%s': %w", pattern, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/store"
+)
+
+type ScheduleService struct {
+	repo        store.Repository
+	config      *config.Config
+	transaction *TransactionService
+}
+
+// ScheduleInput is a recurring transaction as entered by the user. The
+// Timestamp and Status of Transaction are not used: each occurrence is dated
+// on its own day and posted as cleared, or as pending without AutoPost.
+type ScheduleInput struct {
+	Transaction TransactionInput
+	Frequency   string
+	Interval    int
+	DayOfMonth  int   // monthly and yearly only, 0 uses the day of StartDate
+	StartDate   int64 // first possible occurrence
+	EndDate     int64 // last possible occurrence, 0 repeats forever
+	AutoPost    bool
+}
+
+// ScheduleDetail is a stored schedule with its splits resolved to account
+// names and the state of its postings.
+type ScheduleDetail struct {
+	model.ScheduledTransaction
+	Splits     []TransactionSplitInput
+	Posted     int   // number of occurrences posted so far
+	LastPosted int64 // latest posted occurrence, 0 when none
+	Next       int64 // first occurrence not posted yet, 0 when the schedule has ended
+}
+
+// ScheduledPosting is a transaction posted for an occurrence of a schedule.
+type ScheduledPosting struct {
+	ScheduleID    int64
+	TransactionID int64
+	Timestamp     int64
+	Description   string
+	Status        int
+	Splits        []TransactionSplitInput
+}
+
+func NewScheduleService(repo store.Repository, cfg *config.Config, transaction *TransactionService) *ScheduleService {
+	return &ScheduleService{repo: repo, config: cfg, transaction: transaction}
+}
+
+// AddSchedule validates and stores a recurring transaction. Nothing is posted
+// until RunSchedules.
+func (ss *ScheduleService) AddSchedule(input ScheduleInput) (int64, error) {
+	schedule := model.ScheduledTransaction{
+		Description: strings.TrimSpace(input.Transaction.Description),
+		Frequency:   strings.ToLower(input.Frequency),
+		Interval:    input.Interval,
+		StartDate:   input.StartDate,
+		AutoPost:    input.AutoPost,
+	}
+	if schedule.Description == "" {
+		return 0, errors.New("description is required")
+	}
+
+	switch schedule.Frequency {
+	case model.FrequencyDaily, model.FrequencyWeekly:
+		if input.DayOfMonth != 0 {
+			return 0, fmt.Errorf("a day of month only applies to monthly and yearly schedules, not %s", schedule.Frequency)
+		}
+	case model.FrequencyMonthly, model.FrequencyYearly:
+		if input.DayOfMonth < 0 || input.DayOfMonth > 31 {
+			return 0, fmt.Errorf("day of month must be between 1 and 31 (got %d)", input.DayOfMonth)
+		}
+		if input.DayOfMonth != 0 {
+			schedule.DayOfMonth = &input.DayOfMonth
+		}
+	default:
+		return 0, fmt.Errorf("invalid frequency '%s', use daily, weekly, monthly or yearly", input.Frequency)
+	}
+
+	if schedule.Interval < 1 {
+		return 0, fmt.Errorf("interval must be at least 1 (got %d)", schedule.Interval)
+	}
+	if schedule.StartDate == 0 {
+		return 0, errors.New("start date is required")
+	}
+	if input.EndDate != 0 {
+		if input.EndDate < input.StartDate {
+			return 0, errors.New("the end date is before the start date")
+		}
+		schedule.EndDate = &input.EndDate
+	}
+
+	if len(input.Transaction.Splits) < constants.MinSplitsCount {
+		return 0, fmt.Errorf("transaction must have at least 2 splits (got %d)", len(input.Transaction.Splits))
+	}
+
+	splits := make([]model.ScheduledSplit, 0, len(input.Transaction.Splits))
+	balance := make([]model.Split, 0, len(input.Transaction.Splits))
+	for i, splitInput := range input.Transaction.Splits {
+		account, err := ss.repo.GetAccountByName(splitInput.AccountName)
+		if err != nil {
+			return 0, fmt.Errorf("split #%d: %w", i+1, err)
+		}
+		if splitInput.Amount == 0 {
+			return 0, fmt.Errorf("split #%d: amount cannot be zero", i+1)
+		}
+
+		currency := ss.config.Defaults.Currency
+		if account.Currency != "" {
+			currency = account.Currency
+		}
+
+		splits = append(splits, model.ScheduledSplit{
+			AccountID:    account.ID,
+			Amount:       splitInput.Amount,
+			Currency:     currency,
+			Memo:         splitInput.Memo,
+			CostAmount:   splitInput.CostAmount,
+			CostCurrency: splitInput.CostCurrency,
+		})
+		balance = append(balance, model.Split{
+			Amount:       splitInput.Amount,
+			Currency:     currency,
+			CostAmount:   splitInput.CostAmount,
+			CostCurrency: splitInput.CostCurrency,
+		})
+	}
+
+	if err := ss.transaction.ValidateSplitsBalance(balance); err != nil {
+		return 0, err
+	}
+
+	var scheduleID int64
+	err := ss.repo.ExecTx(func(repo store.Repository) error {
+		var err error
+		scheduleID, err = repo.CreateScheduledTransaction(schedule, splits)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return scheduleID, nil
+}
+
+// ListSchedules returns all schedules with the occurrence each one posts next.
+func (ss *ScheduleService) ListSchedules() ([]*ScheduleDetail, error) {
+	schedules, err := ss.repo.GetScheduledTransactions()
+	if err != nil {
+		return nil, err
+	}
+	splits, err := ss.repo.GetScheduledSplits()
+	if err != nil {
+		return nil, err
+	}
+	postings, err := ss.repo.GetScheduledPostings()
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := ss.accountNames(ss.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]*ScheduleDetail, 0, len(schedules))
+	for _, schedule := range schedules {
+		posted := make(map[int64]bool, len(postings[schedule.ID]))
+		for _, occurrence := range postings[schedule.ID] {
+			posted[occurrence] = true
+		}
+
+		detail := &ScheduleDetail{
+			ScheduledTransaction: *schedule,
+			Splits:               scheduledSplitInputs(splits[schedule.ID], accounts),
+			Posted:               len(postings[schedule.ID]),
+		}
+		if detail.Posted > 0 {
+			detail.LastPosted = postings[schedule.ID][detail.Posted-1]
+		}
+		forEachOccurrence(schedule, func(date int64) bool {
+			if posted[date] {
+				return true
+			}
+			detail.Next = date
+			return false
+		})
+
+		details = append(details, detail)
+	}
+
+	return details, nil
+}
+
+// DeleteSchedule stops a schedule. Transactions it already posted are kept.
+func (ss *ScheduleService) DeleteSchedule(scheduleID int64) error {
+	return ss.repo.DeleteScheduledTransaction(scheduleID)
+}
+
+// RunSchedules posts every occurrence dated until or earlier that has not been
+// posted yet, and records it as posted so that later runs skip it. The run is
+// a single database transaction: either every due occurrence is posted or
+// none is.
+func (ss *ScheduleService) RunSchedules(until int64) ([]ScheduledPosting, error) {
+	var result []ScheduledPosting
+
+	err := ss.repo.ExecTx(func(repo store.Repository) error {
+		schedules, err := repo.GetScheduledTransactions()
+		if err != nil {
+			return err
+		}
+		splits, err := repo.GetScheduledSplits()
+		if err != nil {
+			return err
+		}
+		postings, err := repo.GetScheduledPostings()
+		if err != nil {
+			return err
+		}
+		accounts, err := ss.accountNames(repo)
+		if err != nil {
+			return err
+		}
+
+		for _, schedule := range schedules {
+			posted := make(map[int64]bool, len(postings[schedule.ID]))
+			for _, occurrence := range postings[schedule.ID] {
+				posted[occurrence] = true
+			}
+
+			status := model.StatusPending
+			if schedule.AutoPost {
+				status = model.StatusCleared
+			}
+			txSplits := toPostedSplits(splits[schedule.ID])
+
+			var postErr error
+			forEachOccurrence(schedule, func(date int64) bool {
+				if date > until {
+					return false
+				}
+				if posted[date] {
+					return true
+				}
+
+				tx := model.Transaction{
+					Timestamp:   date,
+					Description: schedule.Description,
+					Status:      status,
+				}
+				txID, err := repo.CreateTransactionWithSplits(tx, txSplits)
+				if err != nil {
+					postErr = fmt.Errorf("failed to post scheduled transaction #%d: %w", schedule.ID, err)
+					return false
+				}
+				if err := repo.CreateScheduledPosting(schedule.ID, date, txID); err != nil {
+					postErr = err
+					return false
+				}
+
+				result = append(result, ScheduledPosting{
+					ScheduleID:    schedule.ID,
+					TransactionID: txID,
+					Timestamp:     date,
+					Description:   schedule.Description,
+					Status:        status,
+					Splits:        scheduledSplitInputs(splits[schedule.ID], accounts),
+				})
+				return true
+			})
+			if postErr != nil {
+				return postErr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (ss *ScheduleService) accountNames(repo store.Repository) (map[int64]string, error) {
+	accounts, err := repo.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	names := make(map[int64]string, len(accounts))
+	for _, account := range accounts {
+		names[account.ID] = account.Name
+	}
+	return names, nil
+}
+
+// forEachOccurrence calls fn with the date of every occurrence of schedule in
+// order, until fn returns false or the schedule ends.
+func forEachOccurrence(schedule *model.ScheduledTransaction, fn func(date int64) bool) {
+	for n := 0; ; n++ {
+		date := occurrence(schedule, n)
+		if schedule.EndDate != nil && date > *schedule.EndDate {
+			return
+		}
+		// A day of month before the day of the start date skips the first period
+		if date < schedule.StartDate {
+			continue
+		}
+		if !fn(date) {
+			return
+		}
+	}
+}
+
+// occurrence returns the date of the n-th period of schedule, counting from
+// the period of its start date. Monthly and yearly schedules clamp their day
+// to the length of the month, so one on the 31st posts on the last day of
+// shorter months.
+func occurrence(schedule *model.ScheduledTransaction, n int) int64 {
+	start := time.Unix(schedule.StartDate, 0).UTC()
+	step := n * schedule.Interval
+
+	switch schedule.Frequency {
+	case model.FrequencyDaily:
+		return start.AddDate(0, 0, step).Unix()
+	case model.FrequencyWeekly:
+		return start.AddDate(0, 0, 7*step).Unix()
+	}
+
+	year, month := start.Year(), start.Month()
+	if schedule.Frequency == model.FrequencyMonthly {
+		month += time.Month(step)
+	} else {
+		year += step
+	}
+
+	day := start.Day()
+	if schedule.DayOfMonth != nil {
+		day = *schedule.DayOfMonth
+	}
+
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, lastDay)-1).Unix()
+}
+
+func toPostedSplits(splits []*model.ScheduledSplit) []model.Split {
+	result := make([]model.Split, 0, len(splits))
+	for _, split := range splits {
+		result = append(result, model.Split{
+			AccountID:    split.AccountID,
+			Amount:       split.Amount,
+			Currency:     split.Currency,
+			Memo:         split.Memo,
+			CostAmount:   split.CostAmount,
+			CostCurrency: split.CostCurrency,
+		})
+	}
+	return result
+}
+
+func scheduledSplitInputs(splits []*model.ScheduledSplit, accounts map[int64]string) []TransactionSplitInput {
+	result := make([]TransactionSplitInput, 0, len(splits))
+	for _, split := range splits {
+		result = append(result, TransactionSplitInput{
+			AccountID:    split.AccountID,
+			AccountName:  accounts[split.AccountID],
+			Amount:       split.Amount,
+			Currency:     split.Currency,
+			Memo:         split.Memo,
+			CostAmount:   split.CostAmount,
+			CostCurrency: split.CostCurrency,
+		})
+	}
+	return result
+}
@@ -8,13 +8,34 @@ import (
 type Service struct {
 	Account     *AccountService
 	Transaction *TransactionService
+	Report      *ReportService
+	Import      *ImportService
+	Rule        *RuleService
+	Export      *ExportService
+	Reconcile   *ReconcileService
+	Price       *PriceService
+	Commodity   *CommodityService
+	Investment  *InvestmentService
+	Schedule    *ScheduleService
 	Config      *config.Config
 }
 
 func NewService(repo store.Repository, cfg *config.Config) *Service {
+	transaction := NewTransactionService(repo, cfg)
+	rule := NewRuleService(repo, cfg)
+
 	return &Service{
 		Account:     NewAccountService(repo, cfg),
-		Transaction: NewTransactionService(repo, cfg),
+		Transaction: transaction,
+		Report:      NewReportService(repo, cfg, transaction),
+		Import:      NewImportService(repo, cfg, transaction, rule),
+		Rule:        rule,
+		Export:      NewExportService(repo, cfg, transaction),
+		Reconcile:   NewReconcileService(repo, cfg),
+		Price:       NewPriceService(repo, cfg),
+		Commodity:   NewCommodityService(repo, cfg),
+		Investment:  NewInvestmentService(repo, cfg, transaction),
+		Schedule:    NewScheduleService(repo, cfg, transaction),
 		Config:      cfg,
 	}
 }
@@ -25,7 +25,7 @@ func (ts *TransactionService) DetermineType(splits []SplitDetail) (TransactionTy
 	)
 
 	for _, split := range splits {
-		acc, err := ts.repo.GetAccountByID(split.AccountID)
+		accType, err := ts.accountType(split)
 		if err != nil {
 			return TxTypeOther, err
 		}
@@ -34,7 +34,7 @@ func (ts *TransactionService) DetermineType(splits []SplitDetail) (TransactionTy
 			isOpening = true
 		}
 
-		switch acc.Type {
+		switch accType {
 		case "E":
 			hasExpense = true
 			totalExpenseAmount += split.Amount
@@ -95,8 +95,7 @@ func (ts *TransactionService) GetDisplayAccount(splits []SplitDetail, txType str
 	case "Expense":
 		// Find and return the Expense account (E type)
 		for _, split := range splits {
-			account, err := ts.repo.GetAccountByName(split.AccountName)
-			if err == nil && account.Type == "E" {
+			if accType, err := ts.accountType(split); err == nil && accType == "E" {
 				return split.AccountName, nil
 			}
 		}
@@ -104,8 +103,7 @@ func (ts *TransactionService) GetDisplayAccount(splits []SplitDetail, txType str
 	case "Income":
 		// Find and return the Revenue account (R type)
 		for _, split := range splits {
-			account, err := ts.repo.GetAccountByName(split.AccountName)
-			if err == nil && account.Type == "R" {
+			if accType, err := ts.accountType(split); err == nil && accType == "R" {
 				return split.AccountName, nil
 			}
 		}
@@ -114,8 +112,7 @@ func (ts *TransactionService) GetDisplayAccount(splits []SplitDetail, txType str
 		// Find and return the Asset account with positive amount (receiving account)
 		for _, split := range splits {
 			if split.Amount > 0 {
-				account, err := ts.repo.GetAccountByName(split.AccountName)
-				if err == nil && (account.Type == "A" || account.Type == "L") {
+				if accType, err := ts.accountType(split); err == nil && (accType == "A" || accType == "L") {
 					return split.AccountName, nil
 				}
 			}
@@ -124,8 +121,7 @@ func (ts *TransactionService) GetDisplayAccount(splits []SplitDetail, txType str
 	case "Opening":
 		// For opening transactions, return the non-equity account
 		for _, split := range splits {
-			account, err := ts.repo.GetAccountByName(split.AccountName)
-			if err == nil && account.Type != "C" {
+			if accType, err := ts.accountType(split); err == nil && accType != "C" {
 				return split.AccountName, nil
 			}
 		}
@@ -147,6 +143,20 @@ func (ts *TransactionService) GetDisplayAccount(splits []SplitDetail, txType str
 	return "-", nil
 }
 
+// accountType returns the type of a split's account, looking the account up
+// only when the split was not loaded with it.
+func (ts *TransactionService) accountType(split SplitDetail) (string, error) {
+	if split.AccountType != "" {
+		return split.AccountType, nil
+	}
+
+	account, err := ts.repo.GetAccountByID(split.AccountID)
+	if err != nil {
+		return "", err
+	}
+	return account.Type, nil
+}
+
 func (ts *TransactionService) GetDisplayAmount(splits []SplitDetail) (int64, string) {
 	if len(splits) == 0 {
 		return 0, ""
@@ -2,21 +2,34 @@ package service
 
 import (
 	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/hance08/kea/internal/commodity"
 	"github.com/hance08/kea/internal/constants"
 	"github.com/hance08/kea/internal/model"
 	"github.com/hance08/kea/internal/store"
 )
 
 func (ts *TransactionService) CreateOpeningBalance(account *model.Account, amountInCents int64) error {
-	currency := ts.config.Defaults.Currency
-
 	if amountInCents == 0 {
 		return nil
 	}
 
-	openingBalanceAccount, err := ts.repo.GetAccountByName("Equity:OpeningBalances")
+	return ts.repo.ExecTx(func(repo store.Repository) error {
+		return ts.createOpeningBalance(repo, account, amountInCents)
+	})
+}
+
+// createOpeningBalance books the opening balance of account through repo, so
+// it can join a database transaction that is already running.
+func (ts *TransactionService) createOpeningBalance(repo store.Repository, account *model.Account, amountInCents int64) error {
+	currency := account.Currency
+	if currency == "" {
+		currency = ts.config.Defaults.Currency
+	}
+
+	openingBalanceAccount, err := repo.GetAccountByName("Equity:OpeningBalances")
 	if err != nil {
 		return fmt.Errorf("error : can not find 'Equity:OpeningBalances' account, failed to set initial balance")
 	}
@@ -32,7 +45,7 @@ func (ts *TransactionService) CreateOpeningBalance(account *model.Account, amoun
 		balanceAmount = -amountInCents
 		equityAmount = amountInCents
 	default:
-		return fmt.Errorf("only Assets(A) and Liabilities(L) account can set balance")
+		return invalid("only Assets(A) and Liabilities(L) account can set balance")
 	}
 
 	tx := model.Transaction{
@@ -56,11 +69,8 @@ func (ts *TransactionService) CreateOpeningBalance(account *model.Account, amoun
 		},
 	}
 
-	return ts.repo.ExecTx(func(repo store.Repository) error {
-		_, err = ts.repo.CreateTransactionWithSplits(tx, splits)
-		return err
-	})
-
+	_, err = repo.CreateTransactionWithSplits(tx, splits)
+	return err
 }
 
 // CreateTransaction validates and persists a new transaction along with its associated splits.
@@ -77,7 +87,7 @@ func (ts *TransactionService) CreateTransaction(input TransactionInput) (int64,
 	// Validate: According to double-entry bookkeeping principles,
 	// a transaction must consist of at least 2 splits.
 	if len(input.Splits) < 2 {
-		return 0, fmt.Errorf("transaction must have at least 2 splits (got %d)", len(input.Splits))
+		return 0, invalid("transaction must have at least 2 splits (got %d)", len(input.Splits))
 	}
 
 	// Set default timestamp: Use current system time if not provided.
@@ -104,10 +114,12 @@ func (ts *TransactionService) CreateTransaction(input TransactionInput) (int64,
 		}
 
 		splits = append(splits, model.Split{
-			AccountID: account.ID,
-			Amount:    splitInput.Amount,
-			Currency:  splitCurrency,
-			Memo:      splitInput.Memo,
+			AccountID:    account.ID,
+			Amount:       splitInput.Amount,
+			Currency:     splitCurrency,
+			Memo:         splitInput.Memo,
+			CostAmount:   splitInput.CostAmount,
+			CostCurrency: splitInput.CostCurrency,
 		})
 	}
 
@@ -122,6 +134,9 @@ func (ts *TransactionService) CreateTransaction(input TransactionInput) (int64,
 		Description: input.Description,
 		Status:      input.Status,
 	}
+	if input.ExternalID != "" {
+		tx.ExternalID = &input.ExternalID
+	}
 
 	var newTxID int64
 
@@ -154,22 +169,38 @@ func (ts *TransactionService) CreateTransaction(input TransactionInput) (int64,
 // Returns the new TransactionID and the constructed TransactionInput (useful for UI rendering).
 func (ts *TransactionService) CreateSimpleTransaction(fromAccount, toAccount string, amount int64, desc string, timestamp int64, status int) (int64, TransactionInput, error) {
 	if fromAccount == toAccount {
-		return 0, TransactionInput{}, fmt.Errorf("source and destination accounts cannot be the same")
+		return 0, TransactionInput{}, invalid("source and destination accounts cannot be the same")
 	}
 
 	if amount <= 0 {
-		return 0, TransactionInput{}, fmt.Errorf("amount must be positive")
+		return 0, TransactionInput{}, invalid("amount must be positive")
+	}
+
+	// Transfers between currencies need a rate, see CreateConversionTransaction
+	from, err := ts.repo.GetAccountByName(fromAccount)
+	if err != nil {
+		return 0, TransactionInput{}, err
+	}
+	to, err := ts.repo.GetAccountByName(toAccount)
+	if err != nil {
+		return 0, TransactionInput{}, err
+	}
+	if from.Currency != to.Currency {
+		return 0, TransactionInput{}, invalid("%s uses %s and %s uses %s, give the exchange rate with --rate",
+			from.Name, from.Currency, to.Name, to.Currency)
 	}
 
 	splits := []TransactionSplitInput{
 		{
 			AccountName: toAccount,
 			Amount:      amount,
+			Currency:    from.Currency,
 			Memo:        "",
 		},
 		{
 			AccountName: fromAccount,
 			Amount:      -amount,
+			Currency:    from.Currency,
 			Memo:        "",
 		},
 	}
@@ -189,10 +220,79 @@ func (ts *TransactionService) CreateSimpleTransaction(fromAccount, toAccount str
 	return id, input, nil
 }
 
+// CreateConversionTransaction records a transfer between accounts held in
+// different currencies. amount is in the source account's currency and rate
+// is the number of destination currency units per source unit.
+//
+// The foreign-currency split carries its cost in the other currency, preferring
+// the default currency, so the transaction balances in a single currency.
+func (ts *TransactionService) CreateConversionTransaction(fromAccount, toAccount string, amount int64, rate *big.Rat, desc string, timestamp int64, status int) (int64, TransactionInput, error) {
+	if fromAccount == toAccount {
+		return 0, TransactionInput{}, invalid("source and destination accounts cannot be the same")
+	}
+
+	if amount <= 0 {
+		return 0, TransactionInput{}, invalid("amount must be positive")
+	}
+
+	from, err := ts.repo.GetAccountByName(fromAccount)
+	if err != nil {
+		return 0, TransactionInput{}, err
+	}
+	to, err := ts.repo.GetAccountByName(toAccount)
+	if err != nil {
+		return 0, TransactionInput{}, err
+	}
+
+	if from.Currency == to.Currency {
+		return 0, TransactionInput{}, invalid("an exchange rate only applies between different currencies, both accounts use %s", from.Currency)
+	}
+
+	converted := commodity.Convert(amount, from.Currency, to.Currency, rate)
+	if converted == 0 {
+		return 0, TransactionInput{}, invalid("converted amount rounds to zero")
+	}
+
+	toSplit := TransactionSplitInput{
+		AccountName: to.Name,
+		Amount:      converted,
+		Currency:    to.Currency,
+	}
+	fromSplit := TransactionSplitInput{
+		AccountName: from.Name,
+		Amount:      -amount,
+		Currency:    from.Currency,
+	}
+
+	if to.Currency == ts.config.Defaults.Currency {
+		cost := -converted
+		fromSplit.CostAmount = &cost
+		fromSplit.CostCurrency = to.Currency
+	} else {
+		cost := amount
+		toSplit.CostAmount = &cost
+		toSplit.CostCurrency = from.Currency
+	}
+
+	input := TransactionInput{
+		Timestamp:   timestamp,
+		Description: desc,
+		Status:      status,
+		Splits:      []TransactionSplitInput{toSplit, fromSplit},
+	}
+
+	id, err := ts.CreateTransaction(input)
+	if err != nil {
+		return 0, TransactionInput{}, err
+	}
+
+	return id, input, nil
+}
+
 // DeleteTransaction deletes a transaction
 func (ts *TransactionService) DeleteTransaction(txID int64) error {
 	if txID == 1 {
-		return fmt.Errorf("operation denied: cannot delete the initial opening transaction")
+		return invalid("operation denied: cannot delete the initial opening transaction")
 	}
 
 	tx, _, err := ts.repo.GetTransactionByID(txID)
@@ -201,7 +301,15 @@ func (ts *TransactionService) DeleteTransaction(txID int64) error {
 	}
 
 	if tx.Status == model.StatusReconciled {
-		return fmt.Errorf("operation Denied: Transaction #%d has been reconciled and cannot be deleted", tx.ID)
+		return invalid("operation Denied: Transaction #%d has been reconciled and cannot be deleted", tx.ID)
+	}
+
+	sold, err := ts.repo.TransactionHasSoldLots(txID)
+	if err != nil {
+		return err
+	}
+	if sold {
+		return invalid("operation denied: lots bought in transaction #%d have been sold, delete the sales first", txID)
 	}
 	return ts.repo.DeleteTransaction(txID)
 }
@@ -211,8 +319,18 @@ func (ts *TransactionService) DeleteTransaction(txID int64) error {
 func (ts *TransactionService) UpdateTransactionStatus(txID int64, status int) error {
 
 	// Business Rule: Restrict status updates to valid enum constants to ensure data integrity.
+	// Reconciled status can only be set through the reconciliation workflow.
 	if status != model.StatusPending && status != model.StatusCleared {
-		return fmt.Errorf("invalid status: must be 0 (Pending) or 1 (Cleared)")
+		return invalid("invalid status: must be 0 (Pending) or 1 (Cleared)")
+	}
+
+	tx, _, err := ts.repo.GetTransactionByID(txID)
+	if err != nil {
+		return err
+	}
+
+	if tx.Status == model.StatusReconciled {
+		return invalid("operation denied: transaction #%d has been reconciled", txID)
 	}
 	return ts.repo.UpdateTransactionStatus(txID, status)
 }
@@ -222,7 +340,7 @@ func (ts *TransactionService) UpdateTransactionStatus(txID int64, status int) er
 func (ts *TransactionService) UpdateTransactionComplete(txID int64, description string, timestamp int64, status int, splits []TransactionSplitInput) error {
 	// Validate status
 	if status != model.StatusPending && status != model.StatusCleared && status != model.StatusReconciled {
-		return fmt.Errorf("invalid status: must be 0 (Pending), 1 (Cleared) or 2 (Reconciled)")
+		return invalid("invalid status: must be 0 (Pending), 1 (Cleared) or 2 (Reconciled)")
 	}
 
 	oldTx, _, err := ts.repo.GetTransactionByID(txID)
@@ -232,29 +350,35 @@ func (ts *TransactionService) UpdateTransactionComplete(txID int64, description
 
 	if oldTx.Status == model.StatusReconciled {
 		if status == model.StatusReconciled {
-			return fmt.Errorf("operation denied: transaction #%d has been reconciled", txID)
+			return invalid("operation denied: transaction #%d has been reconciled", txID)
 		}
 	}
 
+	// Lots keep their own copy of quantities and costs, so investment
+	// transactions are deleted and entered again instead.
+	hasLots, err := ts.repo.TransactionHasLots(txID)
+	if err != nil {
+		return err
+	}
+	if hasLots {
+		return invalid("operation denied: transaction #%d buys or sells lots and cannot be edited", txID)
+	}
+
 	// Validate that we have at least 2 splits
 	if len(splits) < 2 {
-		return fmt.Errorf("transaction must have at least 2 splits for double-entry bookkeeping")
+		return invalid("transaction must have at least 2 splits for double-entry bookkeeping")
 	}
 
 	// Validate splits balance
-	var total int64
-	for _, split := range splits {
-		total += split.Amount
-	}
-	if total != 0 {
-		return fmt.Errorf("splits must balance to zero (current sum: %d)", total)
+	if err := ts.ValidateSplitsBalance(toModelSplits(splits)); err != nil {
+		return err
 	}
 
 	// Validate all accounts exist
 	for _, split := range splits {
 		_, err := ts.repo.GetAccountByID(split.AccountID)
 		if err != nil {
-			return fmt.Errorf("account ID %d not found", split.AccountID)
+			return invalid("account ID %d not found", split.AccountID)
 		}
 	}
 
@@ -281,15 +405,22 @@ func (ts *TransactionService) UpdateTransactionComplete(txID int64, description
 
 		newSplitMap := make(map[int64]bool)
 		for _, split := range splits {
-			if split.ID != 0 {
-				newSplitMap[split.ID] = true
+			if split.ID == 0 {
+				continue
+			}
+			if _, ok := existingSplitMap[split.ID]; !ok {
+				return invalid("split #%d does not belong to transaction #%d", split.ID, txID)
 			}
+			if newSplitMap[split.ID] {
+				return invalid("split #%d is listed more than once", split.ID)
+			}
+			newSplitMap[split.ID] = true
 		}
 
 		// Delete splits that are no longer present
 		for id := range existingSplitMap {
 			if !newSplitMap[id] {
-				if err := repo.DeleteSplit(id); err != nil {
+				if err := repo.DeleteSplit(txID, id); err != nil {
 					return fmt.Errorf("failed to delete split: %w", err)
 				}
 			}
@@ -305,6 +436,8 @@ func (ts *TransactionService) UpdateTransactionComplete(txID int64, description
 					Amount:        split.Amount,
 					Currency:      split.Currency,
 					Memo:          split.Memo,
+					CostAmount:    split.CostAmount,
+					CostCurrency:  split.CostCurrency,
 				}
 				_, err := repo.CreateSplit(txID, newSplit)
 				if err != nil {
@@ -312,7 +445,16 @@ func (ts *TransactionService) UpdateTransactionComplete(txID int64, description
 				}
 			} else {
 				// Update existing split
-				if err := repo.UpdateSplit(split.ID, split.AccountID, split.Amount, split.Currency, split.Memo); err != nil {
+				if err := repo.UpdateSplit(&model.Split{
+					ID:            split.ID,
+					TransactionID: txID,
+					AccountID:     split.AccountID,
+					Amount:        split.Amount,
+					Currency:      split.Currency,
+					Memo:          split.Memo,
+					CostAmount:    split.CostAmount,
+					CostCurrency:  split.CostCurrency,
+				}); err != nil {
 					return err
 				}
 			}
@@ -326,10 +468,13 @@ func (ts *TransactionService) IsEditable(detail *TransactionDetail) bool {
 		return false
 	}
 
-	// (Future feature)
-	// if detail.Status == model.StatusReconciled {
-	//     return false
-	// }
+	if detail.Status == model.StatusReconciled {
+		return false
+	}
+
+	if detail.HasLots {
+		return false
+	}
 
 	return true
 }
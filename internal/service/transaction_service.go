@@ -2,6 +2,8 @@ package service
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/hance08/kea/internal/config"
 	"github.com/hance08/kea/internal/model"
@@ -44,39 +46,149 @@ func (ts *TransactionService) GetTransactionRule(mode string) (TransactionRule,
 
 // GetTransactionByID retrieves a transaction with all split details
 func (ts *TransactionService) GetTransactionByID(txID int64) (*TransactionDetail, error) {
-	tx, splits, err := ts.repo.GetTransactionByID(txID)
+	tx, _, err := ts.repo.GetTransactionByID(txID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to detail format with account names
-	detail := &TransactionDetail{
-		ID:          tx.ID,
-		Timestamp:   tx.Timestamp,
-		Description: tx.Description,
-		Status:      tx.Status,
-		Splits:      make([]SplitDetail, 0, len(splits)),
+	details, err := ts.GetTransactionDetails([]*model.Transaction{tx})
+	if err != nil {
+		return nil, err
+	}
+	return details[0], nil
+}
+
+// GetTransactionDetails loads the splits of all given transactions, with the
+// name and type of their accounts, in bulk rather than per transaction.
+// Details are returned in the order of transactions.
+func (ts *TransactionService) GetTransactionDetails(transactions []*model.Transaction) ([]*TransactionDetail, error) {
+	ids := make([]int64, len(transactions))
+	for i, tx := range transactions {
+		ids[i] = tx.ID
+	}
+
+	splits, err := ts.repo.GetSplitsByTransactions(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]*TransactionDetail, 0, len(transactions))
+	for _, tx := range transactions {
+		detail := &TransactionDetail{
+			ID:          tx.ID,
+			Timestamp:   tx.Timestamp,
+			Description: tx.Description,
+			Status:      tx.Status,
+			Splits:      make([]SplitDetail, 0, len(splits[tx.ID])),
+		}
+
+		for _, split := range splits[tx.ID] {
+			if split.HasLot {
+				detail.HasLots = true
+			}
+			detail.Splits = append(detail.Splits, SplitDetail{
+				ID:          split.ID,
+				AccountID:   split.AccountID,
+				AccountName: split.AccountName,
+				AccountType: split.AccountType,
+				Amount:      split.Amount,
+				Currency:    split.Currency,
+				Memo:        split.Memo,
+
+				CostAmount:   split.CostAmount,
+				CostCurrency: split.CostCurrency,
+			})
+		}
+		details = append(details, detail)
+	}
+
+	return details, nil
+}
+
+// SearchTransactions returns the page of transactions selected by filter,
+// newest first unless it asks for another order.
+func (ts *TransactionService) SearchTransactions(filter TransactionFilter) (*TransactionPage, error) {
+	q := store.NewTransactionQuery()
+
+	if filter.From != 0 {
+		q.From(filter.From)
+	}
+	if filter.To != 0 {
+		q.To(filter.To)
+	}
+
+	amountCurrency := strings.ToUpper(filter.Currency)
+	if amountCurrency == "" {
+		amountCurrency = ts.config.Defaults.Currency
+	}
+	if filter.MinAmount != nil {
+		q.MinAmount(*filter.MinAmount, amountCurrency)
+	}
+	if filter.MaxAmount != nil {
+		q.MaxAmount(*filter.MaxAmount, amountCurrency)
+	}
+	if filter.Text != "" {
+		q.Contains(filter.Text)
+	}
+	if filter.Pattern != "" {
+		if _, err := regexp.Compile(filter.Pattern); err != nil {
+			return nil, invalid("invalid pattern: %w", err)
+		}
+		q.Matches(filter.Pattern)
+	}
+	if len(filter.Statuses) > 0 {
+		q.Status(filter.Statuses...)
+	}
+	if filter.Type != "" {
+		q.Type(string(filter.Type))
+	}
+	if filter.Currency != "" {
+		q.Currency(strings.ToUpper(filter.Currency))
 	}
 
-	for _, split := range splits {
-		// Get account name by ID
-		account, err := ts.repo.GetAccountByID(split.AccountID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get account for split: %w", err)
+	if filter.Account != "" {
+		if name, subtree := strings.CutSuffix(filter.Account, ":*"); subtree {
+			if _, root, isRoot := rootType(name); isRoot {
+				q.AccountTree(root)
+			} else {
+				account, err := ts.repo.GetAccountByName(name)
+				if err != nil {
+					return nil, fmt.Errorf("account not found: %w", err)
+				}
+				q.AccountTree(account.Name)
+			}
+		} else {
+			account, err := ts.repo.GetAccountByName(filter.Account)
+			if err != nil {
+				return nil, fmt.Errorf("account not found: %w", err)
+			}
+			q.Account(account.Name)
 		}
+	}
 
-		splitDetail := SplitDetail{
-			ID:          split.ID,
-			AccountID:   split.AccountID,
-			AccountName: account.Name,
-			Amount:      split.Amount,
-			Currency:    split.Currency,
-			Memo:        split.Memo,
+	if filter.SortBy != "" || filter.Ascending {
+		sortBy := filter.SortBy
+		if sortBy == "" {
+			sortBy = store.SortByDate
 		}
-		detail.Splits = append(detail.Splits, splitDetail)
+		q.OrderBy(sortBy, filter.Ascending)
+	}
+
+	total, err := ts.repo.CountTransactions(q)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := ts.repo.FindTransactions(q.Limit(filter.Limit).Offset(filter.Offset))
+	if err != nil {
+		return nil, err
 	}
 
-	return detail, nil
+	return &TransactionPage{
+		Transactions: transactions,
+		Offset:       filter.Offset,
+		Total:        total,
+	}, nil
 }
 
 // GetRecentTransactions retrieves recent transactions across all accounts
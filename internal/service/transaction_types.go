@@ -2,6 +2,10 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hance08/kea/internal/model"
 )
 
 type TransactionType string
@@ -23,6 +27,45 @@ const (
 	TxTypeOther      TransactionType = "Other"
 )
 
+// ParseTransactionType returns the type named s, ignoring case.
+func ParseTransactionType(s string) (TransactionType, error) {
+	for _, t := range []TransactionType{
+		TxTypeExpense, TxTypeIncome, TxTypeTransfer, TxTypeOpening,
+		TxTypeDeposit, TxTypeWithdrawal, TxTypeOther,
+	} {
+		if strings.EqualFold(string(t), s) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown transaction type '%s' (must be expense, income, transfer, opening, deposit, withdrawal or other)", s)
+}
+
+// TransactionFilter selects transactions for SearchTransactions. Zero values
+// leave a condition out.
+type TransactionFilter struct {
+	From      int64  // start of the date range (Unix timestamp)
+	To        int64  // end of the date range (Unix timestamp)
+	MinAmount *int64 // compared with the largest split in Currency, or the default currency
+	MaxAmount *int64
+	Text      string // substring of the description or a memo
+	Pattern   string // regular expression on the description or a memo
+	Statuses  []int
+	Type      TransactionType
+	Account   string // full name, or "Name:*" to include its sub-accounts
+	Currency  string
+	SortBy    string // store.SortByDate (default), SortByAmount or SortByDescription
+	Ascending bool
+	Limit     int
+	Offset    int
+}
+
+// TransactionPage is one page of transactions found by SearchTransactions.
+type TransactionPage struct {
+	Transactions []*model.Transaction
+	Offset       int
+	Total        int // transactions matching the filter on all pages
+}
+
 // TransactionSplitInput represents a split entry with account name instead of ID
 type TransactionSplitInput struct {
 	ID          int64
@@ -31,6 +74,11 @@ type TransactionSplitInput struct {
 	Amount      int64
 	Currency    string
 	Memo        string
+
+	// CostAmount and CostCurrency give the value of the split in another
+	// currency for multi-currency transactions.
+	CostAmount   *int64
+	CostCurrency string
 }
 
 // TransactionInput represents user input for creating a transaction
@@ -39,6 +87,7 @@ type TransactionInput struct {
 	Description string
 	Splits      []TransactionSplitInput
 	Status      int
+	ExternalID  string // optional, used by importers to skip duplicates
 }
 
 // TransactionDetail represents a transaction with full split details
@@ -48,15 +97,22 @@ type TransactionDetail struct {
 	Description string
 	Status      int
 	Splits      []SplitDetail
+
+	// HasLots is set when the transaction buys or sells investment lots.
+	HasLots bool
 }
 
 type SplitDetail struct {
 	ID          int64
 	AccountID   int64
 	AccountName string
+	AccountType string // empty when the split was not loaded with its account
 	Amount      int64
 	Currency    string
 	Memo        string
+
+	CostAmount   *int64
+	CostCurrency string
 }
 
 func (d *TransactionDetail) ToSplitInputs() []TransactionSplitInput {
@@ -69,6 +125,9 @@ func (d *TransactionDetail) ToSplitInputs() []TransactionSplitInput {
 			Amount:      split.Amount,
 			Currency:    split.Currency,
 			Memo:        split.Memo,
+
+			CostAmount:   split.CostAmount,
+			CostCurrency: split.CostCurrency,
 		})
 	}
 	return inputs
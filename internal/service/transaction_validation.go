@@ -1,53 +1,86 @@
 package service
 
 import (
-	"fmt"
+	"strings"
 
+	"github.com/hance08/kea/internal/commodity"
 	"github.com/hance08/kea/internal/constants"
 	"github.com/hance08/kea/internal/model"
-	"github.com/hance08/kea/internal/utils"
 )
 
-// ValidateSplitsBalance validates that all splits sum to zero (double-entry principle)
+// ValidateSplitsBalance validates that all splits sum to zero (double-entry principle).
+// Splits are summed per currency using their weight, so a split in a foreign
+// currency must carry its cost in the currency of the other side.
 func (ts *TransactionService) ValidateSplitsBalance(splits []model.Split) error {
-	var total int64 = 0
+	totals := make(map[string]int64)
+	var currencies []string
 
 	for _, split := range splits {
-		total += split.Amount
+		amount, currency := split.Weight()
+		if _, ok := totals[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+		totals[currency] += amount
+	}
+
+	var unbalanced []string
+	for _, currency := range currencies {
+		if totals[currency] != 0 {
+			unbalanced = append(unbalanced, commodity.FormatWithCode(totals[currency], currency))
+		}
+	}
+
+	if len(unbalanced) == 0 {
+		return nil
 	}
 
-	if total != 0 {
-		return fmt.Errorf("splits do not balance: total is %d cents (%.2f), must be 0. "+
+	if len(currencies) == 1 {
+		return invalid("splits do not balance: total is %s, must be 0. "+
 			"In double-entry bookkeeping, debits must equal credits",
-			total, float64(total)/100.0)
+			unbalanced[0])
 	}
 
-	return nil
+	return invalid("splits in %s do not balance: %s. "+
+		"Splits in different currencies need an exchange rate to balance",
+		strings.Join(currencies, ", "), strings.Join(unbalanced, ", "))
 }
 
 // ValidateTransactionEdit validates a transaction edit without saving
 func (ts *TransactionService) ValidateTransactionEdit(splits []TransactionSplitInput) error {
 	// Check minimum splits
 	if len(splits) < constants.MinSplitsCount {
-		return fmt.Errorf("transaction must have at least 2 splits")
+		return invalid("transaction must have at least 2 splits")
 	}
 
 	// Check balance
-	var total int64
-	for _, split := range splits {
-		total += split.Amount
-	}
-	if total != 0 {
-		return fmt.Errorf("splits do not balance (sum: %s)", utils.FormatFromCents(total))
+	if err := ts.ValidateSplitsBalance(toModelSplits(splits)); err != nil {
+		return err
 	}
 
 	// Validate accounts exist
 	for i, split := range splits {
 		_, err := ts.repo.GetAccountByID(split.AccountID)
 		if err != nil {
-			return fmt.Errorf("split #%d: account ID %d not found", i+1, split.AccountID)
+			return invalid("split #%d: account ID %d not found", i+1, split.AccountID)
 		}
 	}
 
 	return nil
 }
+
+// toModelSplits converts split inputs so they can be checked for balance.
+func toModelSplits(inputs []TransactionSplitInput) []model.Split {
+	splits := make([]model.Split, 0, len(inputs))
+	for _, input := range inputs {
+		splits = append(splits, model.Split{
+			ID:           input.ID,
+			AccountID:    input.AccountID,
+			Amount:       input.Amount,
+			Currency:     input.Currency,
+			Memo:         input.Memo,
+			CostAmount:   input.CostAmount,
+			CostCurrency: input.CostCurrency,
+		})
+	}
+	return splits
+}
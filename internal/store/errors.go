@@ -1,9 +1,31 @@
 package store
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrAccountExists       = errors.New("account already exists")
 	ErrRecordNotFound      = errors.New("record not found")
 	ErrConstraintViolation = errors.New("database constraint violation")
+	ErrDuplicateExternalID = errors.New("duplicate external_id")
 )
+
+// notFoundError describes a missing record in its own words while still
+// matching ErrRecordNotFound with errors.Is.
+type notFoundError struct {
+	msg string
+}
+
+func (e *notFoundError) Error() string {
+	return e.msg
+}
+
+func (e *notFoundError) Is(target error) bool {
+	return target == ErrRecordNotFound
+}
+
+func notFound(format string, a ...any) error {
+	return &notFoundError{msg: fmt.Sprintf(format, a...)}
+}
@@ -10,6 +10,13 @@ type AccountRepository interface {
 	AccountExists(name string) (bool, error)
 	GetAccountsByType(accType string) ([]*model.Account, error)
 	GetAccountBalance(accountID int64) (int64, error)
+	GetAccountBalances() (map[int64]map[string]int64, error)
+	UpdateAccountPath(id int64, name string, parentID *int64) error
+	SetAccountHidden(id int64, hidden bool) error
+	CountAccountSplits(accountID int64) (int64, error)
+	CountAccountRules(accountID int64) (int64, error)
+	ReassignAccount(fromID, toID int64) (int64, error)
+	DeleteAccount(id int64) error
 }
 
 type TransactionRepository interface {
@@ -18,19 +25,79 @@ type TransactionRepository interface {
 	GetTransactionsByAccount(accountID int64, limit int) ([]*model.Transaction, error)
 	GetTransactionsByDateRange(startTime, endTime int64) ([]*model.Transaction, error)
 	GetAllTransactions(limit int) ([]*model.Transaction, error)
+	FindTransactions(q *TransactionQuery) ([]*model.Transaction, error)
+	CountTransactions(q *TransactionQuery) (int, error)
 
 	UpdateTransactionStatus(txID int64, status int) error
 	DeleteTransaction(txID int64) error
 	UpdateTransactionBasic(txID int64, description string, timestamp int64, status int) error
 
 	CreateSplit(txID int64, split *model.Split) (int64, error)
-	UpdateSplit(splitID int64, accountID int64, amount int64, currency string, memo string) error
-	DeleteSplit(splitID int64) error
+	UpdateSplit(split *model.Split) error
+	DeleteSplit(txID, splitID int64) error
 	GetSplitsByTransaction(txID int64) ([]*model.Split, error)
+	GetSplitsByTransactions(txIDs []int64) (map[int64][]*model.AccountSplit, error)
 }
+
+type ReportRepository interface {
+	GetBalancesAsOf(cutoff int64) (map[int64]map[string]int64, error)
+	GetAccountEntriesAsOf(accountID int64, cutoff int64) ([]*model.AccountEntry, error)
+	GetAccountEntries(accountID int64, startTime, endTime int64) ([]*model.AccountEntry, error)
+	GetAccountTotalsByDateRange(startTime, endTime int64) (map[int64]map[string]int64, error)
+	GetCostTotalsAsOf(cutoff int64) ([]model.CostTotal, error)
+	GetMonthlyTotalsByType(accType string, startTime, endTime int64) ([]model.MonthlyTotal, error)
+}
+
+type RuleRepository interface {
+	CreateRule(rule model.Rule) (int64, error)
+	GetAllRules() ([]*model.Rule, error)
+	DeleteRule(ruleID int64) error
+}
+
+type ReconcileRepository interface {
+	GetUnreconciledEntries(accountID int64, cutoff int64) ([]*model.AccountEntry, error)
+	GetReconciledBalance(accountID int64) (int64, error)
+	CreateReconciliation(rec model.Reconciliation) (int64, error)
+}
+
+type PriceRepository interface {
+	UpsertPrice(price model.Price) (int64, error)
+	GetPrices(commodity string) ([]*model.Price, error)
+}
+
+type CommodityRepository interface {
+	GetCommodities() ([]*model.Commodity, error)
+	UpsertCommodity(c model.Commodity) error
+	IsCommodityInUse(code string) (bool, error)
+}
+
+type LotRepository interface {
+	CreateLot(lot model.Lot) (int64, error)
+	CreateLotDisposal(d model.LotDisposal) (int64, error)
+	GetOpenLots(accountID int64) ([]*model.Lot, error)
+	TransactionHasLots(txID int64) (bool, error)
+	TransactionHasSoldLots(txID int64) (bool, error)
+}
+
+type ScheduleRepository interface {
+	CreateScheduledTransaction(schedule model.ScheduledTransaction, splits []model.ScheduledSplit) (int64, error)
+	GetScheduledTransactions() ([]*model.ScheduledTransaction, error)
+	GetScheduledSplits() (map[int64][]*model.ScheduledSplit, error)
+	DeleteScheduledTransaction(id int64) error
+	GetScheduledPostings() (map[int64][]int64, error)
+	CreateScheduledPosting(scheduleID, occurrence, txID int64) error
+}
+
 type Repository interface {
 	AccountRepository
 	TransactionRepository
+	ReportRepository
+	RuleRepository
+	ReconcileRepository
+	PriceRepository
+	CommodityRepository
+	LotRepository
+	ScheduleRepository
 
 	ExecTx(fn func(Repository) error) error
 	Close() error
@@ -7,13 +7,59 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sync"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
-	_ "github.com/mattn/go-sqlite3"
+	sqlite "github.com/mattn/go-sqlite3"
 )
 
+// driverName is go-sqlite3 with a REGEXP function added, which SQLite
+// leaves to the application.
+const driverName = "sqlite3_kea"
+
+func init() {
+	sql.Register(driverName, &sqlite.SQLiteDriver{
+		ConnectHook: func(conn *sqlite.SQLiteConn) error {
+			return conn.RegisterFunc("regexp", matchRegexp, true)
+		},
+	})
+}
+
+// regexpCacheSize bounds regexpCache, whose patterns come from user input.
+const regexpCacheSize = 64
+
+// regexpCache holds compiled patterns, since REGEXP runs once per row. It is
+// emptied when full, which only costs compiling the patterns again.
+var regexpCache = struct {
+	sync.Mutex
+	patterns map[string]*regexp.Regexp
+}{patterns: make(map[string]*regexp.Regexp)}
+
+// matchRegexp implements "value REGEXP pattern".
+func matchRegexp(pattern, value string) (bool, error) {
+	regexpCache.Lock()
+	re, ok := regexpCache.patterns[pattern]
+	regexpCache.Unlock()
+
+	if !ok {
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			return false, err
+		}
+
+		regexpCache.Lock()
+		if len(regexpCache.patterns) >= regexpCacheSize {
+			clear(regexpCache.patterns)
+		}
+		regexpCache.patterns[pattern] = re
+		regexpCache.Unlock()
+	}
+	return re.MatchString(value), nil
+}
+
 type DBTX interface {
 	Exec(query string, args ...any) (sql.Result, error)
 	Prepare(query string) (*sql.Stmt, error)
@@ -31,7 +77,10 @@ func NewStore(dbPath string, migrationsFS fs.FS) (*Store, error) {
 		return nil, fmt.Errorf("can not create database directory %s: %w", dbDir, err)
 	}
 
-	db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on")
+	// Writers wait for each other instead of failing with "database is
+	// locked", and transactions take the write lock up front so two of them
+	// cannot deadlock upgrading from a read.
+	db, err := sql.Open(driverName, dbPath+"?_foreign_keys=on&_busy_timeout=5000&_txlock=immediate")
 	success := false
 	defer func() {
 		if !success {
@@ -69,9 +69,9 @@ func (s *Store) GetAccountByName(name string) (*model.Account, error) {
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("account '%s' doesn't exist", name)
+			return nil, notFound("account '%s' doesn't exist", name)
 		}
-		return nil, fmt.Errorf("failed to query account '%s' : %w", name, ErrRecordNotFound)
+		return nil, fmt.Errorf("failed to query account '%s' : %w", name, err)
 	}
 
 	if parentID.Valid {
@@ -95,9 +95,9 @@ func (s *Store) GetAccountByID(id int64) (*model.Account, error) {
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("account with ID %d not found", id)
+			return nil, notFound("account with ID %d not found", id)
 		}
-		return nil, fmt.Errorf("failed to query account with ID %d: %w", id, ErrRecordNotFound)
+		return nil, fmt.Errorf("failed to query account with ID %d: %w", id, err)
 	}
 
 	if parentID.Valid {
@@ -151,6 +151,175 @@ func (s *Store) GetAccountBalance(accountID int64) (int64, error) {
 	return 0, nil
 }
 
+// GetAccountBalances returns the balance of every account with splits,
+// keyed by account ID, then currency, from a single grouped query.
+func (s *Store) GetAccountBalances() (map[int64]map[string]int64, error) {
+	rows, err := s.db.Query(`
+        SELECT account_id, currency, SUM(amount)
+        FROM splits
+        GROUP BY account_id, currency
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate balances: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	balances := make(map[int64]map[string]int64)
+	for rows.Next() {
+		var accountID, balance int64
+		var currency string
+		if err := rows.Scan(&accountID, &currency, &balance); err != nil {
+			return nil, fmt.Errorf("failed to scan balance: %w", err)
+		}
+		if balances[accountID] == nil {
+			balances[accountID] = make(map[string]int64)
+		}
+		balances[accountID][currency] = balance
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating balances: %w", err)
+	}
+
+	return balances, nil
+}
+
+// UpdateAccountPath sets an account's full name and parent. Descendants are
+// not touched; the caller renames them within the same transaction.
+func (s *Store) UpdateAccountPath(id int64, name string, parentID *int64) error {
+	result, err := s.db.Exec(`
+        UPDATE accounts
+        SET name = ?, parent_id = ?
+        WHERE id = ?
+    `, name, parentID, id)
+	if err != nil {
+		var sqliteErr sqlite.Error
+		if errors.As(err, &sqliteErr) && errors.Is(sqliteErr.Code, sqlite.ErrConstraint) {
+			return fmt.Errorf("failed to rename account to '%s': %w", name, ErrAccountExists)
+		}
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return notFound("account with ID %d not found", id)
+	}
+
+	return nil
+}
+
+// SetAccountHidden archives or unarchives an account.
+func (s *Store) SetAccountHidden(id int64, hidden bool) error {
+	result, err := s.db.Exec(`
+        UPDATE accounts
+        SET is_hidden = ?
+        WHERE id = ?
+    `, hidden, id)
+	if err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return notFound("account with ID %d not found", id)
+	}
+
+	return nil
+}
+
+// CountAccountSplits returns how many splits are posted to an account.
+func (s *Store) CountAccountSplits(accountID int64) (int64, error) {
+	var count int64
+	err := s.db.QueryRow(`
+        SELECT COUNT(*)
+        FROM splits
+        WHERE account_id = ?
+    `, accountID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count splits: %w", err)
+	}
+	return count, nil
+}
+
+// CountAccountRules returns how many rules target an account or match on it
+// as their source.
+func (s *Store) CountAccountRules(accountID int64) (int64, error) {
+	var count int64
+	err := s.db.QueryRow(`
+        SELECT COUNT(*)
+        FROM rules
+        WHERE target_account_id = ? OR source_account_id = ?
+    `, accountID, accountID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count rules: %w", err)
+	}
+	return count, nil
+}
+
+// ReassignAccount points everything that references fromID, i.e. splits,
+// lots, reconciliations and rules, to toID. It returns the number of splits
+// moved.
+func (s *Store) ReassignAccount(fromID, toID int64) (int64, error) {
+	result, err := s.db.Exec(`
+        UPDATE splits
+        SET account_id = ?
+        WHERE account_id = ?
+    `, toID, fromID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reassign splits: %w", err)
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	updates := []string{
+		`UPDATE lots SET account_id = ? WHERE account_id = ?`,
+		`UPDATE reconciliations SET account_id = ? WHERE account_id = ?`,
+		`UPDATE rules SET source_account_id = ? WHERE source_account_id = ?`,
+		`UPDATE rules SET target_account_id = ? WHERE target_account_id = ?`,
+		`UPDATE scheduled_splits SET account_id = ? WHERE account_id = ?`,
+	}
+	for _, query := range updates {
+		if _, err := s.db.Exec(query, toID, fromID); err != nil {
+			return 0, fmt.Errorf("failed to reassign account references: %w", err)
+		}
+	}
+
+	return moved, nil
+}
+
+// DeleteAccount removes an account. It fails while splits, lots,
+// reconciliations, scheduled transactions or sub-accounts still reference it;
+// rules using it are deleted along with it.
+func (s *Store) DeleteAccount(id int64) error {
+	result, err := s.db.Exec(`
+        DELETE FROM accounts
+        WHERE id = ?
+    `, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return notFound("account with ID %d not found", id)
+	}
+
+	return nil
+}
+
 func (s *Store) scanAccounts(rows *sql.Rows) ([]*model.Account, error) {
 	var accounts []*model.Account
 	for rows.Next() {
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/model"
+)
+
+func (s *Store) GetCommodities() ([]*model.Commodity, error) {
+	rows, err := s.db.Query(`
+        SELECT code, precision
+        FROM commodities
+        ORDER BY code
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commodities: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var commodities []*model.Commodity
+	for rows.Next() {
+		c := &model.Commodity{}
+		if err := rows.Scan(&c.Code, &c.Precision); err != nil {
+			return nil, fmt.Errorf("failed to scan commodity: %w", err)
+		}
+		commodities = append(commodities, c)
+	}
+
+	return commodities, rows.Err()
+}
+
+// UpsertCommodity registers a commodity or changes its precision.
+func (s *Store) UpsertCommodity(c model.Commodity) error {
+	_, err := s.db.Exec(`
+        INSERT INTO commodities (code, precision)
+        VALUES (?, ?)
+        ON CONFLICT (code) DO UPDATE SET precision = excluded.precision
+    `, c.Code, c.Precision)
+	if err != nil {
+		return fmt.Errorf("failed to save commodity: %w", err)
+	}
+	return nil
+}
+
+// IsCommodityInUse reports whether any account or split is held in code.
+func (s *Store) IsCommodityInUse(code string) (bool, error) {
+	var inUse bool
+	err := s.db.QueryRow(`
+        SELECT EXISTS (SELECT 1 FROM accounts WHERE currency = ?)
+            OR EXISTS (SELECT 1 FROM splits WHERE currency = ? OR cost_currency = ?)
+    `, code, code, code).Scan(&inUse)
+	if err != nil {
+		return false, fmt.Errorf("failed to check commodity usage: %w", err)
+	}
+	return inUse, nil
+}
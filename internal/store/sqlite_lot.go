@@ -0,0 +1,102 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/model"
+)
+
+func (s *Store) CreateLot(lot model.Lot) (int64, error) {
+	var id int64
+	err := s.db.QueryRow(`
+        INSERT INTO lots (account_id, split_id, date, quantity, cost_amount, cost_currency)
+        VALUES (?, ?, ?, ?, ?, ?)
+        RETURNING id;
+    `, lot.AccountID, lot.SplitID, lot.Date, lot.Quantity, lot.CostAmount, lot.CostCurrency).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert lot: %w", err)
+	}
+	return id, nil
+}
+
+func (s *Store) CreateLotDisposal(d model.LotDisposal) (int64, error) {
+	var id int64
+	err := s.db.QueryRow(`
+        INSERT INTO lot_disposals (lot_id, split_id, quantity, cost_amount)
+        VALUES (?, ?, ?, ?)
+        RETURNING id;
+    `, d.LotID, d.SplitID, d.Quantity, d.CostAmount).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert lot disposal: %w", err)
+	}
+	return id, nil
+}
+
+// GetOpenLots returns lots with units left, oldest first. An accountID of 0
+// returns the open lots of every account.
+func (s *Store) GetOpenLots(accountID int64) ([]*model.Lot, error) {
+	rows, err := s.db.Query(`
+        SELECT l.id, l.account_id, l.split_id, l.date, l.quantity, l.cost_amount, l.cost_currency,
+               l.quantity - COALESCE(SUM(d.quantity), 0),
+               l.cost_amount - COALESCE(SUM(d.cost_amount), 0)
+        FROM lots l
+        LEFT JOIN lot_disposals d ON d.lot_id = l.id
+        WHERE ? = 0 OR l.account_id = ?
+        GROUP BY l.id
+        HAVING l.quantity - COALESCE(SUM(d.quantity), 0) > 0
+        ORDER BY l.date, l.id
+    `, accountID, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lots: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var lots []*model.Lot
+	for rows.Next() {
+		lot := &model.Lot{}
+		if err := rows.Scan(&lot.ID, &lot.AccountID, &lot.SplitID, &lot.Date, &lot.Quantity,
+			&lot.CostAmount, &lot.CostCurrency, &lot.Remaining, &lot.RemainingCost); err != nil {
+			return nil, fmt.Errorf("failed to scan lot: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+
+	return lots, rows.Err()
+}
+
+// TransactionHasLots reports whether the transaction bought or sold lots.
+func (s *Store) TransactionHasLots(txID int64) (bool, error) {
+	var hasLots bool
+	err := s.db.QueryRow(`
+        SELECT EXISTS (
+            SELECT 1 FROM splits sp
+            WHERE sp.transaction_id = ?
+              AND (EXISTS (SELECT 1 FROM lots l WHERE l.split_id = sp.id)
+                OR EXISTS (SELECT 1 FROM lot_disposals d WHERE d.split_id = sp.id))
+        )
+    `, txID).Scan(&hasLots)
+	if err != nil {
+		return false, fmt.Errorf("failed to check lots: %w", err)
+	}
+	return hasLots, nil
+}
+
+// TransactionHasSoldLots reports whether units of a lot bought by the
+// transaction have been sold since.
+func (s *Store) TransactionHasSoldLots(txID int64) (bool, error) {
+	var sold bool
+	err := s.db.QueryRow(`
+        SELECT EXISTS (
+            SELECT 1 FROM lot_disposals d
+            JOIN lots l ON l.id = d.lot_id
+            JOIN splits sp ON sp.id = l.split_id
+            WHERE sp.transaction_id = ?
+        )
+    `, txID).Scan(&sold)
+	if err != nil {
+		return false, fmt.Errorf("failed to check lot disposals: %w", err)
+	}
+	return sold, nil
+}
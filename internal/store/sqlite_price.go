@@ -0,0 +1,51 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/hance08/kea/internal/model"
+)
+
+// UpsertPrice stores a price, replacing the rate if one already exists for
+// the same day and pair.
+func (s *Store) UpsertPrice(price model.Price) (int64, error) {
+	var id int64
+	err := s.db.QueryRow(`
+        INSERT INTO prices (date, base, quote, rate)
+        VALUES (?, ?, ?, ?)
+        ON CONFLICT (date, base, quote) DO UPDATE SET rate = excluded.rate
+        RETURNING id;
+    `, price.Date, price.Base, price.Quote, price.Rate).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save price: %w", err)
+	}
+	return id, nil
+}
+
+// GetPrices returns prices newest first. If commodity is not empty, only
+// pairs where it is the base or the quote are returned.
+func (s *Store) GetPrices(commodity string) ([]*model.Price, error) {
+	rows, err := s.db.Query(`
+        SELECT id, date, base, quote, rate
+        FROM prices
+        WHERE ? = '' OR base = ? OR quote = ?
+        ORDER BY date DESC, base, quote
+    `, commodity, commodity, commodity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prices: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var prices []*model.Price
+	for rows.Next() {
+		price := &model.Price{}
+		if err := rows.Scan(&price.ID, &price.Date, &price.Base, &price.Quote, &price.Rate); err != nil {
+			return nil, fmt.Errorf("failed to scan price: %w", err)
+		}
+		prices = append(prices, price)
+	}
+
+	return prices, rows.Err()
+}
@@ -0,0 +1,89 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hance08/kea/internal/model"
+)
+
+// GetUnreconciledEntries returns the account's pending and cleared splits
+// dated on or before cutoff, oldest first.
+func (s *Store) GetUnreconciledEntries(accountID int64, cutoff int64) ([]*model.AccountEntry, error) {
+	rows, err := s.db.Query(`
+        SELECT t.id, t.timestamp, t.description, t.status, s.id, s.amount, s.currency, s.memo,
+               s.cost_amount, COALESCE(s.cost_currency, '')
+        FROM splits s
+        INNER JOIN transactions t ON t.id = s.transaction_id
+        WHERE s.account_id = ? AND t.status != ? AND t.timestamp <= ?
+        ORDER BY t.timestamp, t.id, s.id
+    `, accountID, model.StatusReconciled, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unreconciled entries: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return s.scanAccountEntries(rows)
+}
+
+// GetReconciledBalance sums the account's splits that are already reconciled.
+func (s *Store) GetReconciledBalance(accountID int64) (int64, error) {
+	var balance sql.NullInt64
+	err := s.db.QueryRow(`
+        SELECT SUM(s.amount)
+        FROM splits s
+        INNER JOIN transactions t ON t.id = s.transaction_id
+        WHERE s.account_id = ? AND t.status = ?
+    `, accountID, model.StatusReconciled).Scan(&balance)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate reconciled balance: %w", err)
+	}
+
+	if balance.Valid {
+		return balance.Int64, nil
+	}
+	return 0, nil
+}
+
+func (s *Store) CreateReconciliation(rec model.Reconciliation) (int64, error) {
+	var newID int64
+	err := s.db.QueryRow(`
+        INSERT INTO reconciliations (account_id, statement_date, ending_balance, created_at)
+        VALUES (?, ?, ?, ?)
+        RETURNING id;
+    `, rec.AccountID, rec.StatementDate, rec.EndingBalance, rec.CreatedAt).Scan(&newID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert reconciliation: %w", err)
+	}
+
+	return newID, nil
+}
+
+func (s *Store) scanAccountEntries(rows *sql.Rows) ([]*model.AccountEntry, error) {
+	var entries []*model.AccountEntry
+	for rows.Next() {
+		entry := &model.AccountEntry{}
+		var description, memo sql.NullString
+
+		err := rows.Scan(
+			&entry.TransactionID, &entry.Timestamp, &description, &entry.Status,
+			&entry.SplitID, &entry.Amount, &entry.Currency, &memo,
+			&entry.CostAmount, &entry.CostCurrency,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account entry: %w", err)
+		}
+
+		entry.Description = description.String
+		entry.Memo = memo.String
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return entries, nil
+}
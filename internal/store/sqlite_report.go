@@ -0,0 +1,186 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hance08/kea/internal/model"
+)
+
+// GetBalancesAsOf sums split amounts per account and currency for all
+// transactions dated on or before cutoff. Accounts without any splits are
+// omitted.
+func (s *Store) GetBalancesAsOf(cutoff int64) (map[int64]map[string]int64, error) {
+	rows, err := s.db.Query(`
+        SELECT s.account_id, s.currency, SUM(s.amount)
+        FROM splits s
+        INNER JOIN transactions t ON t.id = s.transaction_id
+        WHERE t.timestamp <= ?
+        GROUP BY s.account_id, s.currency
+    `, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balances: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return s.scanAccountTotals(rows)
+}
+
+// GetAccountEntriesAsOf returns the account's splits dated on or before
+// cutoff, oldest first.
+func (s *Store) GetAccountEntriesAsOf(accountID int64, cutoff int64) ([]*model.AccountEntry, error) {
+	rows, err := s.db.Query(`
+        SELECT t.id, t.timestamp, t.description, t.status, s.id, s.amount, s.currency, s.memo,
+               s.cost_amount, COALESCE(s.cost_currency, '')
+        FROM splits s
+        INNER JOIN transactions t ON t.id = s.transaction_id
+        WHERE s.account_id = ? AND t.timestamp <= ?
+        ORDER BY t.timestamp, t.id, s.id
+    `, accountID, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account entries: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return s.scanAccountEntries(rows)
+}
+
+// GetAccountEntries returns the account's splits dated within
+// [startTime, endTime], oldest first.
+func (s *Store) GetAccountEntries(accountID int64, startTime, endTime int64) ([]*model.AccountEntry, error) {
+	rows, err := s.db.Query(`
+        SELECT t.id, t.timestamp, t.description, t.status, s.id, s.amount, s.currency, s.memo,
+               s.cost_amount, COALESCE(s.cost_currency, '')
+        FROM splits s
+        INNER JOIN transactions t ON t.id = s.transaction_id
+        WHERE s.account_id = ? AND t.timestamp >= ? AND t.timestamp <= ?
+        ORDER BY t.timestamp, t.id, s.id
+    `, accountID, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account entries: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return s.scanAccountEntries(rows)
+}
+
+// GetAccountTotalsByDateRange sums split amounts per account and currency for all
+// transactions dated within [startTime, endTime].
+func (s *Store) GetAccountTotalsByDateRange(startTime, endTime int64) (map[int64]map[string]int64, error) {
+	rows, err := s.db.Query(`
+        SELECT s.account_id, s.currency, SUM(s.amount)
+        FROM splits s
+        INNER JOIN transactions t ON t.id = s.transaction_id
+        WHERE t.timestamp >= ? AND t.timestamp <= ?
+        GROUP BY s.account_id, s.currency
+    `, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account totals by date range: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return s.scanAccountTotals(rows)
+}
+
+// GetMonthlyTotalsByType sums split amounts per account and calendar month (UTC)
+// for accounts of the given type within [startTime, endTime].
+func (s *Store) GetMonthlyTotalsByType(accType string, startTime, endTime int64) ([]model.MonthlyTotal, error) {
+	rows, err := s.db.Query(`
+        SELECT s.account_id, strftime('%Y-%m', t.timestamp, 'unixepoch') AS month, SUM(s.amount)
+        FROM splits s
+        INNER JOIN transactions t ON t.id = s.transaction_id
+        INNER JOIN accounts a ON a.id = s.account_id
+        WHERE a.type = ? AND t.timestamp >= ? AND t.timestamp <= ?
+        GROUP BY s.account_id, month
+        ORDER BY month
+    `, accType, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query monthly totals: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var totals []model.MonthlyTotal
+	for rows.Next() {
+		var total model.MonthlyTotal
+		if err := rows.Scan(&total.AccountID, &total.Month, &total.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan monthly total: %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return totals, nil
+}
+
+// scanAccountTotals reads (account_id, currency, total) rows into totals
+// keyed by account ID, then currency.
+func (s *Store) scanAccountTotals(rows *sql.Rows) (map[int64]map[string]int64, error) {
+	totals := make(map[int64]map[string]int64)
+	for rows.Next() {
+		var accountID int64
+		var currency string
+		var total sql.NullInt64
+		if err := rows.Scan(&accountID, &currency, &total); err != nil {
+			return nil, fmt.Errorf("failed to scan account total: %w", err)
+		}
+		if !total.Valid {
+			continue
+		}
+		if totals[accountID] == nil {
+			totals[accountID] = make(map[string]int64)
+		}
+		totals[accountID][currency] = total.Int64
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return totals, nil
+}
+
+// GetCostTotalsAsOf sums the splits that carry a cost, per currency and cost
+// currency, for all transactions dated on or before cutoff.
+func (s *Store) GetCostTotalsAsOf(cutoff int64) ([]model.CostTotal, error) {
+	rows, err := s.db.Query(`
+        SELECT s.currency, SUM(s.amount), s.cost_currency, SUM(s.cost_amount)
+        FROM splits s
+        INNER JOIN transactions t ON t.id = s.transaction_id
+        WHERE t.timestamp <= ? AND s.cost_amount IS NOT NULL
+        GROUP BY s.currency, s.cost_currency
+    `, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cost totals: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var totals []model.CostTotal
+	for rows.Next() {
+		var total model.CostTotal
+		if err := rows.Scan(&total.Currency, &total.Amount, &total.CostCurrency, &total.CostAmount); err != nil {
+			return nil, fmt.Errorf("failed to scan cost total: %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return totals, nil
+}
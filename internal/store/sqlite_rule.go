@@ -0,0 +1,98 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hance08/kea/internal/model"
+)
+
+func (s *Store) CreateRule(rule model.Rule) (int64, error) {
+	var pattern sql.NullString
+	if rule.DescriptionPattern != "" {
+		pattern = sql.NullString{String: rule.DescriptionPattern, Valid: true}
+	}
+
+	var newID int64
+	err := s.db.QueryRow(`
+        INSERT INTO rules (priority, description_pattern, min_amount, max_amount, source_account_id, target_account_id)
+        VALUES (?, ?, ?, ?, ?, ?)
+        RETURNING id;
+    `, rule.Priority, pattern, rule.MinAmount, rule.MaxAmount, rule.SourceAccountID, rule.TargetAccountID).Scan(&newID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert rule: %w", err)
+	}
+
+	return newID, nil
+}
+
+// GetAllRules returns rules in evaluation order.
+func (s *Store) GetAllRules() ([]*model.Rule, error) {
+	rows, err := s.db.Query(`
+        SELECT id, priority, description_pattern, min_amount, max_amount, source_account_id, target_account_id
+        FROM rules
+        ORDER BY priority DESC, id
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rules: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var rules []*model.Rule
+	for rows.Next() {
+		rule := &model.Rule{}
+		var pattern sql.NullString
+		var minAmount, maxAmount, sourceID sql.NullInt64
+
+		err := rows.Scan(
+			&rule.ID, &rule.Priority, &pattern,
+			&minAmount, &maxAmount, &sourceID,
+			&rule.TargetAccountID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rule: %w", err)
+		}
+
+		rule.DescriptionPattern = pattern.String
+		if minAmount.Valid {
+			rule.MinAmount = &minAmount.Int64
+		}
+		if maxAmount.Valid {
+			rule.MaxAmount = &maxAmount.Int64
+		}
+		if sourceID.Valid {
+			rule.SourceAccountID = &sourceID.Int64
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (s *Store) DeleteRule(ruleID int64) error {
+	result, err := s.db.Exec(`
+        DELETE FROM rules
+        WHERE id = ?
+    `, ruleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return notFound("rule with ID %d not found", ruleID)
+	}
+
+	return nil
+}
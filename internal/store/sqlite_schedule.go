@@ -0,0 +1,190 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hance08/kea/internal/model"
+)
+
+// CreateScheduledTransaction inserts a schedule and the splits it posts.
+// It relies on the caller (Service layer) to wrap it in ExecTx for atomicity.
+func (s *Store) CreateScheduledTransaction(schedule model.ScheduledTransaction, splits []model.ScheduledSplit) (int64, error) {
+	var newID int64
+	err := s.db.QueryRow(`
+        INSERT INTO scheduled_transactions (description, frequency, interval, day_of_month, start_date, end_date, auto_post)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+        RETURNING id;
+    `, schedule.Description, schedule.Frequency, schedule.Interval, schedule.DayOfMonth,
+		schedule.StartDate, schedule.EndDate, schedule.AutoPost).Scan(&newID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert scheduled transaction: %w", err)
+	}
+
+	for _, split := range splits {
+		_, err := s.db.Exec(`
+            INSERT INTO scheduled_splits (schedule_id, account_id, amount, currency, memo, cost_amount, cost_currency)
+            VALUES (?, ?, ?, ?, ?, ?, ?)
+        `, newID, split.AccountID, split.Amount, split.Currency, split.Memo,
+			split.CostAmount, nullString(split.CostCurrency))
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert scheduled split (account_id: %d): %w", split.AccountID, err)
+		}
+	}
+
+	return newID, nil
+}
+
+// GetScheduledTransactions returns all schedules in the order they were added.
+func (s *Store) GetScheduledTransactions() ([]*model.ScheduledTransaction, error) {
+	rows, err := s.db.Query(`
+        SELECT id, description, frequency, interval, day_of_month, start_date, end_date, auto_post
+        FROM scheduled_transactions
+        ORDER BY id
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled transactions: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var schedules []*model.ScheduledTransaction
+	for rows.Next() {
+		schedule := &model.ScheduledTransaction{}
+		var dayOfMonth, endDate sql.NullInt64
+
+		err := rows.Scan(
+			&schedule.ID, &schedule.Description, &schedule.Frequency, &schedule.Interval,
+			&dayOfMonth, &schedule.StartDate, &endDate, &schedule.AutoPost,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled transaction: %w", err)
+		}
+
+		if dayOfMonth.Valid {
+			day := int(dayOfMonth.Int64)
+			schedule.DayOfMonth = &day
+		}
+		if endDate.Valid {
+			schedule.EndDate = &endDate.Int64
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// GetScheduledSplits returns the splits of every schedule, keyed by schedule ID.
+func (s *Store) GetScheduledSplits() (map[int64][]*model.ScheduledSplit, error) {
+	rows, err := s.db.Query(`
+        SELECT id, schedule_id, account_id, amount, currency, memo,
+               cost_amount, COALESCE(cost_currency, '')
+        FROM scheduled_splits
+        ORDER BY schedule_id, id
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled splits: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	splits := make(map[int64][]*model.ScheduledSplit)
+	for rows.Next() {
+		split := &model.ScheduledSplit{}
+		var costAmount sql.NullInt64
+
+		err := rows.Scan(
+			&split.ID, &split.ScheduleID, &split.AccountID, &split.Amount,
+			&split.Currency, &split.Memo, &costAmount, &split.CostCurrency,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled split: %w", err)
+		}
+
+		if costAmount.Valid {
+			split.CostAmount = &costAmount.Int64
+		}
+
+		splits[split.ScheduleID] = append(splits[split.ScheduleID], split)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return splits, nil
+}
+
+// DeleteScheduledTransaction removes a schedule with its splits and the
+// record of its postings. The transactions it posted are kept.
+func (s *Store) DeleteScheduledTransaction(id int64) error {
+	result, err := s.db.Exec(`
+        DELETE FROM scheduled_transactions
+        WHERE id = ?
+    `, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete scheduled transaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return notFound("scheduled transaction with ID %d not found", id)
+	}
+
+	return nil
+}
+
+// GetScheduledPostings returns the occurrences posted so far, keyed by
+// schedule ID.
+func (s *Store) GetScheduledPostings() (map[int64][]int64, error) {
+	rows, err := s.db.Query(`
+        SELECT schedule_id, occurrence
+        FROM scheduled_postings
+        ORDER BY schedule_id, occurrence
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled postings: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	postings := make(map[int64][]int64)
+	for rows.Next() {
+		var scheduleID, occurrence int64
+		if err := rows.Scan(&scheduleID, &occurrence); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled posting: %w", err)
+		}
+		postings[scheduleID] = append(postings[scheduleID], occurrence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return postings, nil
+}
+
+// CreateScheduledPosting records that an occurrence of a schedule has been
+// posted as txID. Recording the same occurrence twice fails.
+func (s *Store) CreateScheduledPosting(scheduleID, occurrence, txID int64) error {
+	_, err := s.db.Exec(`
+        INSERT INTO scheduled_postings (schedule_id, occurrence, transaction_id)
+        VALUES (?, ?, ?)
+    `, scheduleID, occurrence, txID)
+	if err != nil {
+		return fmt.Errorf("failed to record posting of scheduled transaction #%d: %w", scheduleID, err)
+	}
+	return nil
+}
@@ -31,15 +31,15 @@ func (s *Store) CreateTransactionWithSplits(tx model.Transaction, splits []model
 		var sqliteErr sqlite.Error
 		if errors.As(err, &sqliteErr) {
 			if errors.Is(sqliteErr.Code, sqlite.ErrConstraint) || errors.Is(sqliteErr.ExtendedCode, sqlite.ErrConstraintUnique) {
-				return 0, fmt.Errorf("transaction already exists (duplicate external_id)")
+				return 0, fmt.Errorf("transaction already exists: %w", ErrDuplicateExternalID)
 			}
 		}
 		return 0, fmt.Errorf("failed to insert transaction: %w", err)
 	}
 
 	stmtSplit, err := s.db.Prepare(`
-        INSERT INTO splits (transaction_id, account_id, amount, currency, memo)
-        VALUES (?, ?, ?, ?, ?);
+        INSERT INTO splits (transaction_id, account_id, amount, currency, memo, cost_amount, cost_currency)
+        VALUES (?, ?, ?, ?, ?, ?, ?);
     `)
 	if err != nil {
 		return 0, fmt.Errorf("failed to prepare split SQL: %w", err)
@@ -49,7 +49,8 @@ func (s *Store) CreateTransactionWithSplits(tx model.Transaction, splits []model
 	}()
 
 	for _, split := range splits {
-		_, err := stmtSplit.Exec(newTxID, split.AccountID, split.Amount, split.Currency, split.Memo)
+		_, err := stmtSplit.Exec(newTxID, split.AccountID, split.Amount, split.Currency, split.Memo,
+			split.CostAmount, nullString(split.CostCurrency))
 		if err != nil {
 			return 0, fmt.Errorf("failed to insert split (account_id: %d): %w", split.AccountID, err)
 		}
@@ -68,13 +69,14 @@ func (s *Store) GetTransactionByID(txID int64) (*model.Transaction, []*model.Spl
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil, fmt.Errorf("transaction with ID %d not found", txID)
+			return nil, nil, notFound("transaction with ID %d not found", txID)
 		}
 		return nil, nil, fmt.Errorf("failed to query transaction: %w", err)
 	}
 
 	rows, err := s.db.Query(`
-        SELECT id, transaction_id, account_id, amount, currency, memo
+        SELECT id, transaction_id, account_id, amount, currency, memo,
+               cost_amount, COALESCE(cost_currency, '')
         FROM splits
         WHERE transaction_id = ?
         ORDER BY id
@@ -96,6 +98,8 @@ func (s *Store) GetTransactionByID(txID int64) (*model.Transaction, []*model.Spl
 			&split.Amount,
 			&split.Currency,
 			&split.Memo,
+			&split.CostAmount,
+			&split.CostCurrency,
 		)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to scan split: %w", err)
@@ -110,6 +114,73 @@ func (s *Store) GetTransactionByID(txID int64) (*model.Transaction, []*model.Spl
 	return &tx, splits, nil
 }
 
+// splitBatchSize caps the number of transaction IDs bound in one query,
+// staying well below SQLite's limit on host parameters.
+const splitBatchSize = 500
+
+// GetSplitsByTransactions loads the splits of many transactions together
+// with their account's name and type, keyed by transaction ID. Splits are in
+// insertion order. It runs one query per batch of splitBatchSize IDs.
+func (s *Store) GetSplitsByTransactions(txIDs []int64) (map[int64][]*model.AccountSplit, error) {
+	result := make(map[int64][]*model.AccountSplit, len(txIDs))
+
+	for start := 0; start < len(txIDs); start += splitBatchSize {
+		batch := txIDs[start:min(start+splitBatchSize, len(txIDs))]
+
+		args := make([]any, len(batch))
+		for i, id := range batch {
+			args[i] = id
+		}
+		rows, err := s.db.Query(`
+            SELECT s.id, s.transaction_id, s.account_id, s.amount, s.currency, s.memo,
+                   s.cost_amount, COALESCE(s.cost_currency, ''),
+                   a.name, a.type,
+                   EXISTS (SELECT 1 FROM lots l WHERE l.split_id = s.id)
+                       OR EXISTS (SELECT 1 FROM lot_disposals d WHERE d.split_id = s.id)
+            FROM splits s
+            INNER JOIN accounts a ON a.id = s.account_id
+            WHERE s.transaction_id IN (`+placeholders(len(batch))+`)
+            ORDER BY s.transaction_id, s.id
+        `, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query splits: %w", err)
+		}
+
+		err = func() error {
+			defer func() {
+				_ = rows.Close()
+			}()
+
+			for rows.Next() {
+				split := &model.AccountSplit{}
+				err := rows.Scan(
+					&split.ID,
+					&split.TransactionID,
+					&split.AccountID,
+					&split.Amount,
+					&split.Currency,
+					&split.Memo,
+					&split.CostAmount,
+					&split.CostCurrency,
+					&split.AccountName,
+					&split.AccountType,
+					&split.HasLot,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to scan split: %w", err)
+				}
+				result[split.TransactionID] = append(result[split.TransactionID], split)
+			}
+			return rows.Err()
+		}()
+		if err != nil {
+			return nil, fmt.Errorf("error iterating splits: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
 func (s *Store) GetTransactionsByAccount(accountID int64, limit int) ([]*model.Transaction, error) {
 	if limit <= 0 {
 		limit = 100
@@ -187,7 +258,7 @@ func (s *Store) UpdateTransactionStatus(txID int64, status int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("transaction with ID %d not found", txID)
+		return notFound("transaction with ID %d not found", txID)
 	}
 
 	return nil
@@ -208,7 +279,7 @@ func (s *Store) DeleteTransaction(txID int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("transaction with ID %d not found", txID)
+		return notFound("transaction with ID %d not found", txID)
 	}
 
 	return nil
@@ -230,18 +301,21 @@ func (s *Store) UpdateTransactionBasic(txID int64, description string, timestamp
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("transaction with ID %d not found", txID)
+		return notFound("transaction with ID %d not found", txID)
 	}
 
 	return nil
 }
 
-func (s *Store) UpdateSplit(splitID int64, accountID int64, amount int64, currency string, memo string) error {
+// UpdateSplit rewrites a split of split.TransactionID. Splits of other
+// transactions are not touched and report not found.
+func (s *Store) UpdateSplit(split *model.Split) error {
 	result, err := s.db.Exec(`
         UPDATE splits
-        SET account_id = ?, amount = ?, currency = ?, memo = ?
-        WHERE id = ?
-    `, accountID, amount, currency, memo, splitID)
+        SET account_id = ?, amount = ?, currency = ?, memo = ?, cost_amount = ?, cost_currency = ?
+        WHERE id = ? AND transaction_id = ?
+    `, split.AccountID, split.Amount, split.Currency, split.Memo,
+		split.CostAmount, nullString(split.CostCurrency), split.ID, split.TransactionID)
 	if err != nil {
 		return fmt.Errorf("failed to update split: %w", err)
 	}
@@ -252,17 +326,19 @@ func (s *Store) UpdateSplit(splitID int64, accountID int64, amount int64, curren
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("split with ID %d not found", splitID)
+		return notFound("split with ID %d not found in transaction #%d", split.ID, split.TransactionID)
 	}
 
 	return nil
 }
 
-func (s *Store) DeleteSplit(splitID int64) error {
+// DeleteSplit removes a split of txID. Splits of other transactions are not
+// touched and report not found.
+func (s *Store) DeleteSplit(txID, splitID int64) error {
 	result, err := s.db.Exec(`
         DELETE FROM splits
-        WHERE id = ?
-    `, splitID)
+        WHERE id = ? AND transaction_id = ?
+    `, splitID, txID)
 	if err != nil {
 		return fmt.Errorf("failed to delete split: %w", err)
 	}
@@ -273,7 +349,7 @@ func (s *Store) DeleteSplit(splitID int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("split with ID %d not found", splitID)
+		return notFound("split with ID %d not found in transaction #%d", splitID, txID)
 	}
 
 	return nil
@@ -281,9 +357,10 @@ func (s *Store) DeleteSplit(splitID int64) error {
 
 func (s *Store) CreateSplit(txID int64, split *model.Split) (int64, error) {
 	result, err := s.db.Exec(`
-        INSERT INTO splits (transaction_id, account_id, amount, currency, memo)
-        VALUES (?, ?, ?, ?, ?)
-    `, txID, split.AccountID, split.Amount, split.Currency, split.Memo)
+        INSERT INTO splits (transaction_id, account_id, amount, currency, memo, cost_amount, cost_currency)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+    `, txID, split.AccountID, split.Amount, split.Currency, split.Memo,
+		split.CostAmount, nullString(split.CostCurrency))
 	if err != nil {
 		return 0, fmt.Errorf("failed to create split: %w", err)
 	}
@@ -298,7 +375,8 @@ func (s *Store) CreateSplit(txID int64, split *model.Split) (int64, error) {
 
 func (s *Store) GetSplitsByTransaction(txID int64) ([]*model.Split, error) {
 	rows, err := s.db.Query(`
-        SELECT id, transaction_id, account_id, amount, currency, memo
+        SELECT id, transaction_id, account_id, amount, currency, memo,
+               cost_amount, COALESCE(cost_currency, '')
         FROM splits
         WHERE transaction_id = ?
         ORDER BY id
@@ -320,6 +398,8 @@ func (s *Store) GetSplitsByTransaction(txID int64) ([]*model.Split, error) {
 			&split.Amount,
 			&split.Currency,
 			&split.Memo,
+			&split.CostAmount,
+			&split.CostCurrency,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan split: %w", err)
@@ -347,3 +427,11 @@ func (s *Store) scanTransactions(rows *sql.Rows) ([]*model.Transaction, error) {
 
 	return transactions, nil
 }
+
+// nullString stores empty strings as NULL.
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
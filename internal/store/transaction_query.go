@@ -0,0 +1,259 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/model"
+)
+
+// Fields transactions can be sorted by with TransactionQuery.OrderBy.
+const (
+	SortByDate        = "date"
+	SortByAmount      = "amount"
+	SortByDescription = "description"
+)
+
+// transactionAmountSQL is the amount shown for a transaction, its largest
+// positive split, as in TransactionService.GetDisplayAmount.
+const transactionAmountSQL = `(
+    SELECT MAX(0, COALESCE(MAX(s.amount), 0))
+    FROM splits s
+    WHERE s.transaction_id = t.id
+)`
+
+// transactionTypeSQL classifies a transaction from the types of the accounts
+// it touches, following the same rules as TransactionService.DetermineType.
+// It takes the opening balance memo as its only argument.
+const transactionTypeSQL = `(
+    SELECT CASE
+        WHEN COUNT(*) = 0 THEN 'Other'
+        WHEN MAX(s.memo = ?) = 1 THEN 'Opening'
+        WHEN SUM(a.type = 'E') > 0 AND SUM(a.type = 'R') > 0 THEN
+            CASE WHEN SUM(CASE WHEN a.type = 'R' THEN ABS(s.amount) ELSE 0 END)
+                   >= SUM(CASE WHEN a.type = 'E' THEN s.amount ELSE 0 END)
+                 THEN 'Income' ELSE 'Expense' END
+        WHEN SUM(a.type = 'E') > 0 AND SUM(a.type IN ('A', 'L')) > 0 THEN 'Expense'
+        WHEN SUM(a.type = 'R') > 0 AND SUM(a.type IN ('A', 'L')) > 0 THEN 'Income'
+        WHEN SUM(a.type IN ('A', 'L')) >= 2 THEN 'Transfer'
+        WHEN SUM(a.type = 'C') > 0 AND SUM(a.type IN ('A', 'L')) > 0 THEN
+            CASE WHEN MAX(a.type = 'A' AND s.amount > 0) = 1 THEN 'Deposit' ELSE 'Withdrawal' END
+        ELSE 'Other'
+    END
+    FROM splits s
+    INNER JOIN accounts a ON a.id = s.account_id
+    WHERE s.transaction_id = t.id
+)`
+
+// splitAmountSQL is the largest split of a transaction in the currency given
+// as its argument.
+const splitAmountSQL = `(
+    SELECT MAX(s.amount)
+    FROM splits s
+    WHERE s.transaction_id = t.id AND s.currency = ?
+)`
+
+var sortColumns = map[string]string{
+	SortByDate:        "t.timestamp",
+	SortByAmount:      transactionAmountSQL,
+	SortByDescription: "t.description COLLATE NOCASE",
+}
+
+// TransactionQuery selects transactions for FindTransactions and
+// CountTransactions. Every method adds one condition and returns the query
+// so calls can be chained; a transaction has to meet all conditions. Without
+// OrderBy, the newest transactions come first.
+type TransactionQuery struct {
+	conditions []string
+	args       []any
+	order      string
+	limit      int
+	offset     int
+	err        error
+}
+
+func NewTransactionQuery() *TransactionQuery {
+	return &TransactionQuery{order: "t.timestamp DESC, t.id DESC"}
+}
+
+func (q *TransactionQuery) where(condition string, args ...any) *TransactionQuery {
+	q.conditions = append(q.conditions, condition)
+	q.args = append(q.args, args...)
+	return q
+}
+
+// From keeps transactions dated at or after timestamp.
+func (q *TransactionQuery) From(timestamp int64) *TransactionQuery {
+	return q.where("t.timestamp >= ?", timestamp)
+}
+
+// To keeps transactions dated at or before timestamp.
+func (q *TransactionQuery) To(timestamp int64) *TransactionQuery {
+	return q.where("t.timestamp <= ?", timestamp)
+}
+
+// MinAmount keeps transactions whose largest split in currency is at least
+// amount.
+func (q *TransactionQuery) MinAmount(amount int64, currency string) *TransactionQuery {
+	return q.where(splitAmountSQL+" >= ?", currency, amount)
+}
+
+// MaxAmount keeps transactions whose largest split in currency is at most
+// amount.
+func (q *TransactionQuery) MaxAmount(amount int64, currency string) *TransactionQuery {
+	return q.where(splitAmountSQL+" <= ?", currency, amount)
+}
+
+// Contains keeps transactions whose description or a split memo contains
+// text, ignoring ASCII case.
+func (q *TransactionQuery) Contains(text string) *TransactionQuery {
+	pattern := "%" + escapeLike(text) + "%"
+	return q.where(`(t.description LIKE ? ESCAPE '\' OR EXISTS (
+        SELECT 1 FROM splits s
+        WHERE s.transaction_id = t.id AND s.memo LIKE ? ESCAPE '\'
+    ))`, pattern, pattern)
+}
+
+// Matches keeps transactions whose description or a split memo matches the
+// regular expression pattern.
+func (q *TransactionQuery) Matches(pattern string) *TransactionQuery {
+	return q.where(`(COALESCE(t.description, '') REGEXP ? OR EXISTS (
+        SELECT 1 FROM splits s
+        WHERE s.transaction_id = t.id AND COALESCE(s.memo, '') REGEXP ?
+    ))`, pattern, pattern)
+}
+
+// Status keeps transactions with any of the given statuses.
+func (q *TransactionQuery) Status(statuses ...int) *TransactionQuery {
+	args := make([]any, len(statuses))
+	for i, status := range statuses {
+		args[i] = status
+	}
+	return q.where("t.status IN ("+placeholders(len(statuses))+")", args...)
+}
+
+// Type keeps transactions of a type such as "Expense" or "Transfer".
+func (q *TransactionQuery) Type(txType string) *TransactionQuery {
+	return q.where(transactionTypeSQL+" = ?", constants.OpeningAccountMemo, txType)
+}
+
+// Account keeps transactions with a split in the named account.
+func (q *TransactionQuery) Account(name string) *TransactionQuery {
+	return q.where(`EXISTS (
+        SELECT 1 FROM splits s
+        INNER JOIN accounts a ON a.id = s.account_id
+        WHERE s.transaction_id = t.id AND a.name = ?
+    )`, name)
+}
+
+// AccountTree keeps transactions with a split in the named account or any
+// of its sub-accounts. name may also be a root such as "Expenses".
+func (q *TransactionQuery) AccountTree(name string) *TransactionQuery {
+	return q.where(`EXISTS (
+        SELECT 1 FROM splits s
+        INNER JOIN accounts a ON a.id = s.account_id
+        WHERE s.transaction_id = t.id AND (a.name = ? OR a.name LIKE ? ESCAPE '\')
+    )`, name, escapeLike(name)+":%")
+}
+
+// Currency keeps transactions with a split in the given currency.
+func (q *TransactionQuery) Currency(code string) *TransactionQuery {
+	return q.where(`EXISTS (
+        SELECT 1 FROM splits s
+        WHERE s.transaction_id = t.id AND s.currency = ?
+    )`, code)
+}
+
+// OrderBy sorts by one of the SortBy fields, ties broken by ID.
+func (q *TransactionQuery) OrderBy(field string, ascending bool) *TransactionQuery {
+	column, ok := sortColumns[field]
+	if !ok {
+		q.err = fmt.Errorf("cannot sort by '%s' (must be %s, %s or %s)", field, SortByDate, SortByAmount, SortByDescription)
+		return q
+	}
+
+	direction := "DESC"
+	if ascending {
+		direction = "ASC"
+	}
+	q.order = fmt.Sprintf("%s %s, t.id %s", column, direction, direction)
+	return q
+}
+
+// Limit caps the number of transactions returned, 0 means no limit.
+func (q *TransactionQuery) Limit(n int) *TransactionQuery {
+	q.limit = n
+	return q
+}
+
+// Offset skips the first n transactions, for paging through results.
+func (q *TransactionQuery) Offset(n int) *TransactionQuery {
+	q.offset = n
+	return q
+}
+
+func (q *TransactionQuery) whereSQL() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+	return "\nWHERE " + strings.Join(q.conditions, "\n  AND ")
+}
+
+// FindTransactions returns the transactions selected by q.
+func (s *Store) FindTransactions(q *TransactionQuery) ([]*model.Transaction, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	query := `
+        SELECT t.id, t.timestamp, t.description, t.status, t.external_id
+        FROM transactions t` + q.whereSQL() + `
+        ORDER BY ` + q.order
+	args := append([]any{}, q.args...)
+
+	if q.limit > 0 || q.offset > 0 {
+		limit := q.limit
+		if limit <= 0 {
+			limit = -1
+		}
+		query += "\nLIMIT ? OFFSET ?"
+		args = append(args, limit, q.offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transactions: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return s.scanTransactions(rows)
+}
+
+// CountTransactions returns how many transactions q selects, ignoring its
+// limit and offset.
+func (s *Store) CountTransactions(q *TransactionQuery) (int, error) {
+	if q.err != nil {
+		return 0, q.err
+	}
+
+	var count int
+	err := s.db.QueryRow(`
+        SELECT COUNT(*)
+        FROM transactions t`+q.whereSQL(), q.args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+	return count, nil
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// escapeLike escapes the LIKE wildcards in s, using \ as the escape character.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -29,12 +29,20 @@ func PromptAccountType() (string, error) {
 	return selectedType, nil
 }
 
-// PromptParentAccount prompts for parent account with autocomplete
+// PromptParentAccount prompts for parent account with autocomplete, archived
+// accounts are left out
 func PromptParentAccount(accounts []*model.Account) (string, *model.Account, error) {
+	if err := ensureInteractive(); err != nil {
+		return "", nil, err
+	}
+
 	accountMap := make(map[string]*model.Account)
 	var options []huh.Option[string]
 
 	for _, acc := range accounts {
+		if acc.IsHidden {
+			continue
+		}
 		accountMap[acc.Name] = acc
 		options = append(options, huh.NewOption(acc.Name, acc.Name))
 	}
@@ -10,6 +10,10 @@ import (
 // PromptDescription prompts for a description text
 // Can be used for transactions, accounts, or any other entity
 func PromptDescription(message string, required bool) (string, error) {
+	if err := ensureInteractive(); err != nil {
+		return "", err
+	}
+
 	var desc string
 
 	input := huh.NewInput().
@@ -31,6 +35,10 @@ func PromptDescription(message string, required bool) (string, error) {
 
 // PromptAmount prompts for an amount with custom validation
 func PromptAmount(message string, helpText string, validator func(string) error) (string, error) {
+	if err := ensureInteractive(); err != nil {
+		return "", err
+	}
+
 	var amount string
 
 	input := huh.NewInput().
@@ -48,6 +56,10 @@ func PromptAmount(message string, helpText string, validator func(string) error)
 
 // PromptConfirm prompts for yes/no confirmation
 func PromptConfirm(message string, defaultValue bool) (bool, error) {
+	if err := ensureInteractive(); err != nil {
+		return false, err
+	}
+
 	confirm := defaultValue
 
 	err := huh.NewConfirm().
@@ -63,6 +75,10 @@ func PromptConfirm(message string, defaultValue bool) (bool, error) {
 
 // PromptDate prompts for a date in YYYY-MM-DD format
 func PromptDate(message string, defaultDate string, helpText string) (string, error) {
+	if err := ensureInteractive(); err != nil {
+		return "", err
+	}
+
 	var date string
 
 	// Use Input for date for now (huh has no specialized date picker yet, simpler to stick to input)
@@ -86,6 +102,10 @@ func PromptDate(message string, defaultDate string, helpText string) (string, er
 
 // PromptInput prompts for a generic text input with optional default and validator
 func PromptInput(message string, defaultValue string, validator func(string) error) (string, error) {
+	if err := ensureInteractive(); err != nil {
+		return "", err
+	}
+
 	var inputVal string
 
 	input := huh.NewInput().
@@ -114,6 +134,10 @@ func PromptInput(message string, defaultValue string, validator func(string) err
 
 // PromptSelect prompts for a selection from a list of options
 func PromptSelect(message string, options []string, defaultOption string) (string, error) {
+	if err := ensureInteractive(); err != nil {
+		return "", err
+	}
+
 	realDefault := defaultOption
 	matchFound := false
 
@@ -0,0 +1,21 @@
+package prompts
+
+import "errors"
+
+// ErrNotInteractive is returned by every prompt once prompts are turned off.
+var ErrNotInteractive = errors.New("this command asks for input, which is not available with --output; pass the values as flags instead")
+
+var interactive = true
+
+// SetInteractive turns prompts on or off. When off, prompts fail with
+// ErrNotInteractive instead of waiting for input, so scripts never hang.
+func SetInteractive(on bool) {
+	interactive = on
+}
+
+func ensureInteractive() error {
+	if !interactive {
+		return ErrNotInteractive
+	}
+	return nil
+}
@@ -63,10 +63,14 @@ func PromptAccountSelection(
 	showBalance bool,
 	balanceGetter func(int64) (string, error),
 ) (string, error) {
-	// find all the father account(container)
+	if err := ensureInteractive(); err != nil {
+		return "", err
+	}
+
+	// find all the father account(container), archived children don't count
 	parentIDs := make(map[int64]bool)
 	for _, acc := range accounts {
-		if acc.ParentID != nil {
+		if acc.ParentID != nil && !acc.IsHidden {
 			parentIDs[*acc.ParentID] = true
 		}
 	}
@@ -8,6 +8,10 @@ import (
 )
 
 func PromptInitCurrency(currDefault string) (string, error) {
+	if err := ensureInteractive(); err != nil {
+		return "", err
+	}
+
 	selection := currDefault
 
 	err := huh.NewSelect[string]().
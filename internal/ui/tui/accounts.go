@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+)
+
+// accountRow is one line of the account tree. The root of each type is a row
+// of its own with a nil account, so its whole section can be browsed.
+type accountRow struct {
+	name    string // full name, e.g. "Assets:Bank"
+	label   string // indented last segment
+	accType string
+	account *model.Account
+	leaf    bool  // has no sub-accounts, so transactions can be added
+	total   int64 // subtree total in the tree currency, with natural sign
+}
+
+// filter returns the account filter of the register: the account itself, or
+// the account and its sub-accounts.
+func (r accountRow) filter() string {
+	if r.leaf {
+		return r.name
+	}
+	return r.name + ":*"
+}
+
+// contains reports whether a split posted to accountName shows up in the
+// register of r.
+func (r accountRow) contains(accountName string) bool {
+	if r.leaf {
+		return accountName == r.name
+	}
+	return accountName == r.name || strings.HasPrefix(accountName, r.name+":")
+}
+
+// accountPane is the account tree on the left.
+type accountPane struct {
+	table    table.Model
+	rows     []accountRow
+	tree     *service.AccountTree
+	netWorth int64
+}
+
+func newAccountPane() accountPane {
+	return accountPane{table: newTable(true)}
+}
+
+// load rebuilds the tree from the visible accounts, keeping the cursor on the
+// same account when it still exists.
+func (p *accountPane) load(svc *service.Service) error {
+	accounts, err := svc.Account.GetAllAccounts()
+	if err != nil {
+		return err
+	}
+	visible := make([]*model.Account, 0, len(accounts))
+	for _, acc := range accounts {
+		if !acc.IsHidden {
+			visible = append(visible, acc)
+		}
+	}
+
+	converter, err := svc.Price.NewConverter()
+	if err != nil {
+		return err
+	}
+	tree, err := svc.Account.GetAccountTree(visible, converter)
+	if err != nil {
+		return err
+	}
+
+	selected := ""
+	if row, ok := p.selected(); ok {
+		selected = row.name
+	}
+
+	p.tree = tree
+	p.rows = p.rows[:0]
+	p.netWorth = 0
+	for _, section := range tree.Sections {
+		p.rows = append(p.rows, accountRow{
+			name:    section.Title,
+			label:   section.Title,
+			accType: section.Type,
+			total:   section.Total,
+		})
+		for _, node := range section.Flatten() {
+			p.rows = append(p.rows, accountRow{
+				name:    node.Account.Name,
+				label:   strings.Repeat("  ", node.Depth+1) + lastSegment(node.Account.Name),
+				accType: node.Account.Type,
+				account: node.Account,
+				leaf:    len(node.Children) == 0,
+				total:   node.Total,
+			})
+		}
+
+		switch section.Type {
+		case "A":
+			p.netWorth += section.Total
+		case "L":
+			p.netWorth -= section.Total
+		}
+	}
+
+	p.render()
+	for i, row := range p.rows {
+		if row.name == selected {
+			p.table.SetCursor(i)
+			break
+		}
+	}
+	return nil
+}
+
+func (p *accountPane) render() {
+	width := p.table.Columns()[1].Width
+	rows := make([]table.Row, 0, len(p.rows))
+	for _, row := range p.rows {
+		rows = append(rows, table.Row{
+			row.label,
+			fmt.Sprintf("%*s", width, commodity.Format(row.total, p.tree.Currency)),
+		})
+	}
+	p.table.SetRows(rows)
+}
+
+// setSize fits the tree into a pane of the given inner size.
+func (p *accountPane) setSize(width, height int) {
+	const balanceWidth = 14
+	p.table.SetColumns([]table.Column{
+		{Title: "Account", Width: max(width-balanceWidth-2*cellPadding, 1)},
+		{Title: "Balance", Width: balanceWidth},
+	})
+	p.table.SetHeight(height)
+	if p.tree != nil {
+		p.render()
+	}
+}
+
+func (p *accountPane) selected() (accountRow, bool) {
+	i := p.table.Cursor()
+	if i < 0 || i >= len(p.rows) {
+		return accountRow{}, false
+	}
+	return p.rows[i], true
+}
+
+// lastSegment returns the part of an account name after the last colon.
+func lastSegment(name string) string {
+	return name[strings.LastIndex(name, ":")+1:]
+}
@@ -0,0 +1,426 @@
+// Package tui is the full-screen dashboard of 'kea tui': the account tree,
+// the register of the selected account and a status bar with the net worth.
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+)
+
+// refreshInterval is how often the screen reloads, so that changes made by
+// other kea commands or 'kea serve' show up.
+const refreshInterval = 5 * time.Second
+
+// cellPadding is the space the table styles put on each side of a cell.
+const cellPadding = 2
+
+var (
+	paneStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("8"))
+	focusedPaneStyle = paneStyle.BorderForeground(lipgloss.Color("6"))
+	titleStyle       = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	statusStyle      = lipgloss.NewStyle().Background(lipgloss.Color("6")).Foreground(lipgloss.Color("0")).Padding(0, 1)
+	messageStyle     = lipgloss.NewStyle().Padding(0, 1)
+	errorStyle       = messageStyle.Foreground(lipgloss.Color("9"))
+	helpStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Padding(0, 1)
+)
+
+const helpText = "tab pane · a add · e edit · d delete · c clear · r reload · q quit"
+
+// Run shows the dashboard until the user quits.
+func Run(svc *service.Service) error {
+	m := &dashboard{
+		svc:      svc,
+		accounts: newAccountPane(),
+		register: newRegisterPane(),
+	}
+	m.accounts.setSize(40, 10)
+	m.register.setSize(80, 10)
+	if err := m.reload(); err != nil {
+		return err
+	}
+
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+type refreshMsg struct{}
+
+func tick() tea.Cmd {
+	return tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+		return refreshMsg{}
+	})
+}
+
+type dashboard struct {
+	svc      *service.Service
+	accounts accountPane
+	register registerPane
+
+	form     *transactionForm // open while adding or editing
+	deleting int64            // transaction waiting for the user to confirm its deletion
+
+	message string
+	failed  bool // message is an error
+
+	width, height int
+}
+
+func (m *dashboard) Init() tea.Cmd {
+	return tick()
+}
+
+func (m *dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.layout()
+		return m, nil
+
+	case refreshMsg:
+		// Leave the screen alone while the user is in the middle of something
+		if m.form == nil && m.deleting == 0 {
+			if err := m.reload(); err != nil {
+				m.setError(err)
+			}
+		}
+		return m, tick()
+	}
+
+	if m.form != nil {
+		return m, m.updateForm(msg)
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.deleting != 0 {
+		m.confirmDelete(keyMsg.String() == "y")
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "tab", "shift+tab":
+		m.switchPane()
+	case "left", "h":
+		if m.register.table.Focused() {
+			m.switchPane()
+		}
+	case "right", "l", "enter":
+		if m.accounts.table.Focused() {
+			m.switchPane()
+		} else if keyMsg.String() == "enter" {
+			return m, m.openEditForm()
+		}
+	case "a":
+		return m, m.openAddForm()
+	case "e":
+		return m, m.openEditForm()
+	case "d":
+		m.askDelete()
+	case "c":
+		m.toggleCleared()
+	case "r":
+		if err := m.reload(); err != nil {
+			m.setError(err)
+		} else {
+			m.setMessage("Reloaded")
+		}
+	default:
+		m.moveCursor(keyMsg)
+	}
+	return m, nil
+}
+
+// moveCursor passes navigation keys to the focused table, loading the
+// register when another account gets selected.
+func (m *dashboard) moveCursor(msg tea.KeyMsg) {
+	if m.register.table.Focused() {
+		m.register.table, _ = m.register.table.Update(msg)
+		return
+	}
+
+	before := m.accounts.table.Cursor()
+	m.accounts.table, _ = m.accounts.table.Update(msg)
+	if m.accounts.table.Cursor() != before {
+		if err := m.loadRegister(); err != nil {
+			m.setError(err)
+		}
+	}
+}
+
+func (m *dashboard) switchPane() {
+	if m.accounts.table.Focused() {
+		m.accounts.table.Blur()
+		m.register.table.Focus()
+	} else {
+		m.register.table.Blur()
+		m.accounts.table.Focus()
+	}
+	m.accounts.table.SetStyles(tableStyles(m.accounts.table.Focused()))
+	m.register.table.SetStyles(tableStyles(m.register.table.Focused()))
+}
+
+// reload reads accounts, balances and the register again.
+func (m *dashboard) reload() error {
+	if err := m.accounts.load(m.svc); err != nil {
+		return err
+	}
+	return m.loadRegister()
+}
+
+func (m *dashboard) loadRegister() error {
+	row, ok := m.accounts.selected()
+	if !ok {
+		return nil
+	}
+	return m.register.load(m.svc, row)
+}
+
+func (m *dashboard) openAddForm() tea.Cmd {
+	row, ok := m.accounts.selected()
+	if !ok {
+		return nil
+	}
+
+	form, err := newAddForm(m.accounts.rows, row)
+	if err != nil {
+		m.setError(err)
+		return nil
+	}
+	return m.openForm(form)
+}
+
+func (m *dashboard) openEditForm() tea.Cmd {
+	entry, ok := m.register.selected()
+	if !ok {
+		return nil
+	}
+
+	form, err := newEditForm(m.svc, m.accounts.rows, m.register.account, entry.detail)
+	if err != nil {
+		m.setError(err)
+		return nil
+	}
+	return m.openForm(form)
+}
+
+func (m *dashboard) openForm(form *transactionForm) tea.Cmd {
+	m.form = form
+	m.message = ""
+	width, _ := m.registerSize()
+	form.form.WithWidth(width)
+	return form.form.Init()
+}
+
+// updateForm passes msg to the open form, and saves or drops the transaction
+// once the form is done.
+func (m *dashboard) updateForm(msg tea.Msg) tea.Cmd {
+	updated, cmd := m.form.form.Update(msg)
+	if f, ok := updated.(*huh.Form); ok {
+		m.form.form = f
+	}
+
+	switch m.form.form.State {
+	case huh.StateCompleted:
+		message, err := m.form.submit(m.svc)
+		m.form = nil
+		if err != nil {
+			m.setError(err)
+		} else {
+			m.setMessage(message)
+		}
+		if err := m.reload(); err != nil {
+			m.setError(err)
+		}
+		return nil
+	case huh.StateAborted:
+		m.form = nil
+		m.setMessage("Cancelled")
+		return nil
+	}
+	return cmd
+}
+
+func (m *dashboard) askDelete() {
+	entry, ok := m.register.selected()
+	if !ok {
+		return
+	}
+	m.deleting = entry.detail.ID
+	m.setMessage(fmt.Sprintf("Delete transaction #%d '%s'? (y/n)", entry.detail.ID, entry.detail.Description))
+}
+
+func (m *dashboard) confirmDelete(confirmed bool) {
+	txID := m.deleting
+	m.deleting = 0
+	if !confirmed {
+		m.setMessage("Cancelled")
+		return
+	}
+
+	if err := m.svc.Transaction.DeleteTransaction(txID); err != nil {
+		m.setError(err)
+		return
+	}
+	m.setMessage(fmt.Sprintf("Transaction #%d deleted", txID))
+	if err := m.reload(); err != nil {
+		m.setError(err)
+	}
+}
+
+// toggleCleared marks a pending transaction as cleared, and a cleared one as
+// pending again.
+func (m *dashboard) toggleCleared() {
+	entry, ok := m.register.selected()
+	if !ok {
+		return
+	}
+
+	status, name := model.StatusCleared, "cleared"
+	if entry.detail.Status == model.StatusCleared {
+		status, name = model.StatusPending, "pending"
+	}
+	if err := m.svc.Transaction.UpdateTransactionStatus(entry.detail.ID, status); err != nil {
+		m.setError(err)
+		return
+	}
+	m.setMessage(fmt.Sprintf("Transaction #%d marked as %s", entry.detail.ID, name))
+	if err := m.reload(); err != nil {
+		m.setError(err)
+	}
+}
+
+func (m *dashboard) setMessage(message string) {
+	m.message, m.failed = message, false
+}
+
+func (m *dashboard) setError(err error) {
+	message := err.Error()
+	m.message, m.failed = strings.ToUpper(message[:1])+message[1:], true
+}
+
+// layout gives the account tree two fifths of the width and the register the
+// rest, above a one-line status bar.
+func (m *dashboard) layout() {
+	accountsWidth, height := m.accountsSize()
+	registerWidth, _ := m.registerSize()
+
+	// One line of each pane is its title
+	m.accounts.setSize(accountsWidth, height-1)
+	m.register.setSize(registerWidth, height-1)
+	if m.form != nil {
+		m.form.form.WithWidth(registerWidth)
+	}
+}
+
+// accountsSize returns the inner size of the account pane.
+func (m *dashboard) accountsSize() (int, int) {
+	width := min(max(m.width*2/5, 30), 60)
+	return width - 2, max(m.height-1-2, 3)
+}
+
+// registerSize returns the inner size of the register pane.
+func (m *dashboard) registerSize() (int, int) {
+	accountsWidth, height := m.accountsSize()
+	return max(m.width-(accountsWidth+2)-2, 1), height
+}
+
+func (m *dashboard) View() string {
+	if m.width == 0 {
+		return ""
+	}
+
+	accountsWidth, height := m.accountsSize()
+	registerWidth, _ := m.registerSize()
+
+	left := m.pane(titleStyle.Render("Accounts")+"\n"+m.accounts.table.View(),
+		accountsWidth, height, m.accounts.table.Focused() && m.form == nil)
+
+	var right string
+	if m.form != nil {
+		right = m.pane(titleStyle.Render(m.form.title)+"\n"+m.form.form.View(), registerWidth, height, true)
+	} else {
+		title := "Register: " + m.register.account.name
+		if acc := m.register.account.account; acc != nil {
+			title += " (" + acc.Currency + ")"
+		}
+		right = m.pane(titleStyle.Render(title)+"\n"+m.register.table.View(),
+			registerWidth, height, m.register.table.Focused())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, left, right),
+		m.statusBar(),
+	)
+}
+
+func (m *dashboard) pane(content string, width, height int, focused bool) string {
+	style := paneStyle
+	if focused {
+		style = focusedPaneStyle
+	}
+	return style.Width(width).Height(height).MaxHeight(height + 2).Render(content)
+}
+
+// statusBar shows the net worth, the outcome of the last action and the keys.
+func (m *dashboard) statusBar() string {
+	tree := m.accounts.tree
+	netWorth := "Net worth " + commodity.FormatWithCode(m.accounts.netWorth, tree.Currency)
+	if tree.MissingRates {
+		netWorth += " (some rates missing)"
+	}
+
+	bar := statusStyle.Render(netWorth)
+	switch {
+	case m.message != "" && m.failed:
+		bar += errorStyle.Render(m.message)
+	case m.message != "":
+		bar += messageStyle.Render(m.message)
+	}
+
+	help := helpText
+	if m.form != nil {
+		help = "enter next field · esc cancel"
+	}
+	gap := m.width - lipgloss.Width(bar) - lipgloss.Width(help) - 2
+	if gap > 0 {
+		bar += strings.Repeat(" ", gap) + helpStyle.Render(help)
+	}
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(bar)
+}
+
+func newTable(focused bool) table.Model {
+	return table.New(
+		table.WithFocused(focused),
+		table.WithStyles(tableStyles(focused)),
+	)
+}
+
+// tableStyles keeps the cursor of the unfocused pane visible, but dimmer.
+func tableStyles(focused bool) table.Styles {
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("8")).
+		BorderBottom(true).
+		Bold(true)
+	if !focused {
+		styles.Selected = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	}
+	return styles
+}
@@ -0,0 +1,242 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/huh"
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/constants"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+)
+
+// transactionForm adds a transaction to the account of the register, or edits
+// one of its transactions. Only transactions between the account and a single
+// other account in the same currency can have their amount changed here;
+// other splits are left to 'kea transaction edit'.
+type transactionForm struct {
+	form    *huh.Form
+	title   string
+	account accountRow
+	detail  *service.TransactionDetail // nil when adding
+
+	// own and other index the two splits of detail when its amount can be
+	// edited, own is -1 otherwise.
+	own, other int
+
+	date        string
+	description string
+	counter     string
+	amount      string
+	status      int
+}
+
+func newAddForm(accounts []accountRow, row accountRow) (*transactionForm, error) {
+	if !row.leaf {
+		return nil, fmt.Errorf("transactions go to accounts without sub-accounts, %s has some", row.name)
+	}
+
+	f := &transactionForm{
+		title:   "New transaction in " + row.name,
+		account: row,
+		date:    time.Now().Format(constants.DateFormat),
+		status:  model.StatusCleared,
+	}
+
+	options := counterOptions(accounts, row.name, row.account.Currency, "")
+	if len(options) == 0 {
+		return nil, fmt.Errorf("no other account uses %s", row.account.Currency)
+	}
+	f.form = f.build(options)
+	return f, nil
+}
+
+func newEditForm(svc *service.Service, accounts []accountRow, row accountRow, detail *service.TransactionDetail) (*transactionForm, error) {
+	if !svc.Transaction.IsEditable(detail) {
+		return nil, fmt.Errorf("transaction #%d cannot be edited (system, reconciled or investment transaction)", detail.ID)
+	}
+
+	f := &transactionForm{
+		title:       fmt.Sprintf("Edit transaction #%d", detail.ID),
+		account:     row,
+		detail:      detail,
+		own:         -1,
+		date:        utils.FormatDate(detail.Timestamp),
+		description: detail.Description,
+		status:      detail.Status,
+	}
+
+	if len(detail.Splits) == 2 && detail.Splits[0].Currency == detail.Splits[1].Currency &&
+		detail.Splits[0].CostAmount == nil && detail.Splits[1].CostAmount == nil {
+		for i, split := range detail.Splits {
+			if row.contains(split.AccountName) && !row.contains(detail.Splits[1-i].AccountName) {
+				f.own, f.other = i, 1-i
+			}
+		}
+	}
+
+	var options []huh.Option[string]
+	if f.own >= 0 {
+		own, other := detail.Splits[f.own], detail.Splits[f.other]
+		f.counter = other.AccountName
+		f.amount = commodity.Format(own.Amount*service.NaturalSign(row.accType), own.Currency)
+
+		// The register may show a subtree, so go by the split itself
+		options = counterOptions(accounts, own.AccountName, own.Currency, other.AccountName)
+	}
+
+	f.form = f.build(options)
+	return f, nil
+}
+
+// build lays out the form, offering options as the other account.
+func (f *transactionForm) build(options []huh.Option[string]) *huh.Form {
+	fields := []huh.Field{
+		huh.NewInput().
+			Title("Date (YYYY-MM-DD)").
+			Value(&f.date).
+			Validate(func(s string) error {
+				_, err := utils.ParseDateStart(s)
+				return err
+			}),
+		huh.NewInput().
+			Title("Description").
+			Value(&f.description),
+	}
+
+	if f.editsAmount() {
+		currency := f.currency()
+		fields = append(fields,
+			huh.NewSelect[string]().
+				Title("Other account").
+				Options(options...).
+				Value(&f.counter),
+			huh.NewInput().
+				Title(fmt.Sprintf("Amount (%s)", currency)).
+				Description("Positive raises the balance of "+f.account.name+", negative lowers it").
+				Value(&f.amount).
+				Validate(func(s string) error {
+					amount, err := commodity.Parse(s, currency)
+					if err != nil {
+						return err
+					}
+					if amount == 0 {
+						return errors.New("amount cannot be zero")
+					}
+					return nil
+				}),
+		)
+	} else {
+		fields = append(fields, huh.NewNote().
+			Title("Splits").
+			Description(fmt.Sprintf("Not a transfer between two accounts in one currency, edit its splits with 'kea transaction edit %d'", f.detail.ID)))
+	}
+
+	fields = append(fields, huh.NewSelect[int]().
+		Title("Status").
+		Options(
+			huh.NewOption("Cleared", model.StatusCleared),
+			huh.NewOption("Pending", model.StatusPending),
+		).
+		Value(&f.status))
+
+	keymap := huh.NewDefaultKeyMap()
+	keymap.Quit = key.NewBinding(key.WithKeys("esc", "ctrl+c"), key.WithHelp("esc", "cancel"))
+
+	return huh.NewForm(huh.NewGroup(fields...)).
+		WithKeyMap(keymap).
+		WithShowHelp(true)
+}
+
+// editsAmount reports whether the form sets the amount and the other account.
+func (f *transactionForm) editsAmount() bool {
+	return f.detail == nil || f.own >= 0
+}
+
+// currency is the currency of the amount being entered.
+func (f *transactionForm) currency() string {
+	if f.detail != nil {
+		return f.detail.Splits[f.own].Currency
+	}
+	return f.account.account.Currency
+}
+
+// submit saves the transaction and returns a message for the status bar.
+func (f *transactionForm) submit(svc *service.Service) (string, error) {
+	timestamp, err := utils.ParseDateStart(f.date)
+	if err != nil {
+		return "", err
+	}
+
+	var amount int64
+	if f.editsAmount() {
+		if amount, err = commodity.Parse(f.amount, f.currency()); err != nil {
+			return "", err
+		}
+		amount *= service.NaturalSign(f.account.accType)
+	}
+
+	if f.detail == nil {
+		txID, err := svc.Transaction.CreateTransaction(service.TransactionInput{
+			Timestamp:   timestamp,
+			Description: strings.TrimSpace(f.description),
+			Status:      f.status,
+			Splits: []service.TransactionSplitInput{
+				{AccountName: f.account.name, Amount: amount},
+				{AccountName: f.counter, Amount: -amount},
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Transaction #%d added", txID), nil
+	}
+
+	// Keep the time of day when the date did not change
+	if utils.FormatDate(f.detail.Timestamp) == f.date {
+		timestamp = f.detail.Timestamp
+	}
+
+	splits := f.detail.ToSplitInputs()
+	if f.own >= 0 {
+		counter, err := svc.Account.GetAccountByName(f.counter)
+		if err != nil {
+			return "", err
+		}
+		splits[f.own].Amount = amount
+		splits[f.other].AccountID = counter.ID
+		splits[f.other].AccountName = counter.Name
+		splits[f.other].Currency = counter.Currency
+		splits[f.other].Amount = -amount
+	}
+
+	err = svc.Transaction.UpdateTransactionComplete(f.detail.ID, strings.TrimSpace(f.description), timestamp, f.status, splits)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Transaction #%d updated", f.detail.ID), nil
+}
+
+// counterOptions offers the accounts a split of account can balance against:
+// the other accounts without sub-accounts in currency. current is kept even if
+// it is no longer offered, e.g. after archiving it.
+func counterOptions(accounts []accountRow, account, currency, current string) []huh.Option[string] {
+	var names []string
+	for _, r := range accounts {
+		if r.leaf && r.name != account && r.account.Currency == currency {
+			names = append(names, r.name)
+		}
+	}
+	if current != "" && !slices.Contains(names, current) {
+		names = append(names, current)
+	}
+	sort.Strings(names)
+	return huh.NewOptions(names...)
+}
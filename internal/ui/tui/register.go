@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+)
+
+// registerEntry is one transaction of the register with the part of it that
+// touches the selected account.
+type registerEntry struct {
+	detail  *service.TransactionDetail
+	counter string           // the other account, or "(split)"
+	amounts map[string]int64 // by currency, with the natural sign of the account
+}
+
+// registerPane lists the transactions of the selected account, newest first.
+type registerPane struct {
+	table   table.Model
+	account accountRow
+	entries []registerEntry
+}
+
+func newRegisterPane() registerPane {
+	return registerPane{table: newTable(false)}
+}
+
+// load fills the register with the transactions of row, keeping the cursor on
+// the same transaction when row is still the selected account.
+func (p *registerPane) load(svc *service.Service, row accountRow) error {
+	var selected int64
+	if entry, ok := p.selected(); ok && row.name == p.account.name {
+		selected = entry.detail.ID
+	}
+
+	page, err := svc.Transaction.SearchTransactions(service.TransactionFilter{Account: row.filter()})
+	if err != nil {
+		return err
+	}
+	details, err := svc.Transaction.GetTransactionDetails(page.Transactions)
+	if err != nil {
+		return err
+	}
+
+	sign := service.NaturalSign(row.accType)
+	p.account = row
+	p.entries = p.entries[:0]
+	for _, detail := range details {
+		entry := registerEntry{detail: detail, amounts: make(map[string]int64)}
+		counters := make(map[string]bool)
+		for _, split := range detail.Splits {
+			if row.contains(split.AccountName) {
+				entry.amounts[split.Currency] += split.Amount * sign
+			} else {
+				counters[split.AccountName] = true
+			}
+		}
+
+		switch len(counters) {
+		case 0:
+			entry.counter = "(within " + row.name + ")"
+		case 1:
+			for name := range counters {
+				entry.counter = name
+			}
+		default:
+			entry.counter = "(split)"
+		}
+		p.entries = append(p.entries, entry)
+	}
+
+	p.render()
+	p.table.SetCursor(0)
+	for i, entry := range p.entries {
+		if entry.detail.ID == selected {
+			p.table.SetCursor(i)
+			break
+		}
+	}
+	return nil
+}
+
+func (p *registerPane) render() {
+	amountWidth := p.table.Columns()[4].Width
+	rows := make([]table.Row, 0, len(p.entries))
+	for _, entry := range p.entries {
+		rows = append(rows, table.Row{
+			utils.FormatDate(entry.detail.Timestamp),
+			statusMarks[entry.detail.Status],
+			entry.detail.Description,
+			entry.counter,
+			fmt.Sprintf("%*s", amountWidth, p.formatAmounts(entry.amounts)),
+		})
+	}
+	p.table.SetRows(rows)
+}
+
+// formatAmounts shows the amount of a single account in its currency, and
+// names the currencies when a subtree mixes them.
+func (p *registerPane) formatAmounts(amounts map[string]int64) string {
+	if p.account.account != nil && len(amounts) == 1 {
+		if amount, ok := amounts[p.account.account.Currency]; ok {
+			return commodity.Format(amount, p.account.account.Currency)
+		}
+	}
+
+	currencies := make([]string, 0, len(amounts))
+	for currency := range amounts {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	parts := make([]string, 0, len(currencies))
+	for _, currency := range currencies {
+		parts = append(parts, commodity.FormatWithCode(amounts[currency], currency))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// setSize fits the register into a pane of the given inner size.
+func (p *registerPane) setSize(width, height int) {
+	const dateWidth, statusWidth, amountWidth = 10, 1, 14
+	flexible := max(width-dateWidth-statusWidth-amountWidth-5*cellPadding, 2)
+	p.table.SetColumns([]table.Column{
+		{Title: "Date", Width: dateWidth},
+		{Title: "", Width: statusWidth},
+		{Title: "Description", Width: flexible / 2},
+		{Title: "Account", Width: flexible - flexible/2},
+		{Title: "Amount", Width: amountWidth},
+	})
+	p.table.SetHeight(height)
+	p.render()
+}
+
+func (p *registerPane) selected() (registerEntry, bool) {
+	i := p.table.Cursor()
+	if i < 0 || i >= len(p.entries) {
+		return registerEntry{}, false
+	}
+	return p.entries[i], true
+}
+
+// statusMarks follows the ledger convention: ! pending, * cleared and R for
+// reconciled.
+var statusMarks = map[int]string{
+	model.StatusPending:    "!",
+	model.StatusCleared:    "*",
+	model.StatusReconciled: "R",
+}
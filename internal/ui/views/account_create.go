@@ -3,6 +3,7 @@ package views
 import (
 	"fmt"
 
+	"github.com/hance08/kea/internal/commodity"
 	"github.com/hance08/kea/internal/ui"
 	"github.com/pterm/pterm"
 )
@@ -18,7 +19,7 @@ type AccountSummaryItem struct {
 func RenderAccountSummary(data AccountSummaryItem) error {
 	ui.Separator()
 
-	balanceStr := fmt.Sprintf("%.2f", float64(data.Balance)/100)
+	balanceStr := commodity.Format(data.Balance, data.Currency)
 
 	descStr := data.Description
 	if descStr == "" {
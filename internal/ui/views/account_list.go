@@ -2,8 +2,11 @@ package views
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
-	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
 	"github.com/pterm/pterm"
 )
 
@@ -13,34 +16,54 @@ func NewAccountListView() *AccountListView {
 	return &AccountListView{}
 }
 
-func (v *AccountListView) Render(accounts []*model.Account, balanceGetter func(int64) (string, error)) error {
+// Render lists accounts with their balances. When some accounts are held in
+// another currency, a column with balances converted into currency is added.
+func (v *AccountListView) Render(balances []service.AccountBalance, currency string) error {
+	if Structured() {
+		return v.renderStructured(balances, currency)
+	}
+
+	multiCurrency := false
+	for _, b := range balances {
+		if b.Account.Currency != currency {
+			multiCurrency = true
+			break
+		}
+	}
+
 	headers := []string{"Name", "Type", "Balance"}
+	if multiCurrency {
+		headers = append(headers, "Balance ("+currency+")")
+	}
 	tableData := pterm.TableData{headers}
 
-	for _, acc := range accounts {
-		balance, _ := balanceGetter(acc.ID)
-		balanceWithCurrency := fmt.Sprintf("%s %s", balance, acc.Currency)
-
-		var coloredAccount, coloredType, coloredBalance string
-		switch acc.Type {
-		case "A", "R": // Assets, Revenue - Green
-			coloredType = pterm.Green(acc.Type)
-			coloredBalance = pterm.Green(balanceWithCurrency)
-			coloredAccount = pterm.Green(acc.Name)
-		case "L", "E": // Liabilities, Expenses - Red
-			coloredType = pterm.Red(acc.Type)
-			coloredBalance = pterm.Red(balanceWithCurrency)
-			coloredAccount = pterm.Red(acc.Name)
-		case "C": // Equity - Gray
-			coloredType = pterm.Gray(acc.Type)
-			coloredBalance = pterm.Gray(balanceWithCurrency)
-			coloredAccount = pterm.Gray(acc.Name)
-		default:
-			coloredType = acc.Type
-			coloredBalance = balanceWithCurrency
-			coloredAccount = acc.Name
-		}
-		tableData = append(tableData, []string{coloredAccount, coloredType, coloredBalance})
+	var netWorth int64
+	hasNetWorth, missingRates := false, false
+
+	for _, b := range balances {
+		acc := b.Account
+		balanceWithCurrency := formatBalance(b.Balance, acc.Currency, b.Other)
+
+		convertedStr := "n/a"
+		if b.Converted != nil {
+			convertedStr = commodity.Format(*b.Converted, currency)
+		}
+
+		if acc.Type == "A" || acc.Type == "L" {
+			hasNetWorth = true
+			if b.Converted != nil {
+				netWorth += *b.Converted
+			} else {
+				missingRates = true
+			}
+		}
+
+		colorize := typeColor(acc.Type)
+		row := []string{colorize(acc.Name), colorize(acc.Type), colorize(balanceWithCurrency)}
+		if multiCurrency {
+			row = append(row, colorize(convertedStr))
+		}
+		tableData = append(tableData, row)
 	}
 
 	pterm.DefaultSection.Printf("Account List")
@@ -48,7 +71,243 @@ func (v *AccountListView) Render(accounts []*model.Account, balanceGetter func(i
 		return err
 	}
 
-	pterm.Info.Printf("Total: %d accounts\n", len(accounts))
+	pterm.Info.Printf("Total: %d accounts\n", len(balances))
+
+	if hasNetWorth {
+		pterm.Info.Printf("Net Worth (A - L): %s\n", commodity.FormatWithCode(netWorth, currency))
+	}
+	if missingRates {
+		pterm.Warning.Printf("Some balances have no %s price and are left out of the net worth, add one with 'kea price add'\n", currency)
+	}
+
+	return nil
+}
+
+// RenderTree lists accounts indented under their parents with their own
+// balance and the total of their subtree. Accounts deeper than depth name
+// segments are collapsed into their parent's total; 0 shows every level.
+func (v *AccountListView) RenderTree(tree *service.AccountTree, depth int) error {
+	if Structured() {
+		return v.renderTreeStructured(tree, depth)
+	}
+
+	tableData := pterm.TableData{
+		{"Account", "Balance", "Total (" + tree.Currency + ")"},
+	}
+
+	count, collapsed := 0, 0
+	var netWorth int64
+	hasNetWorth := false
+
+	for _, section := range tree.Sections {
+		colorize := typeColor(section.Type)
+		tableData = append(tableData, []string{
+			pterm.Bold.Sprint(colorize(section.Title)),
+			"",
+			pterm.Bold.Sprint(colorize(commodity.Format(section.Total, tree.Currency))),
+		})
+
+		for _, node := range section.Flatten() {
+			// The root segment counts as the first level
+			if depth > 0 && node.Depth+2 > depth {
+				collapsed++
+				continue
+			}
+			count++
+
+			acc := node.Account
+			tableData = append(tableData, []string{
+				colorize(strings.Repeat("  ", node.Depth+1) + lastSegment(acc.Name)),
+				colorize(formatBalance(tree.Balances[acc.ID], acc.Currency, tree.Other[acc.ID])),
+				colorize(commodity.Format(node.Total, tree.Currency)),
+			})
+		}
+
+		switch section.Type {
+		case "A":
+			netWorth += section.Total
+			hasNetWorth = true
+		case "L":
+			netWorth -= section.Total
+			hasNetWorth = true
+		}
+	}
+
+	pterm.DefaultSection.Printf("Account Tree")
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	if collapsed > 0 {
+		pterm.Info.Printf("Total: %d accounts shown, %d collapsed into their parents\n", count, collapsed)
+	} else {
+		pterm.Info.Printf("Total: %d accounts\n", count)
+	}
+
+	if hasNetWorth {
+		pterm.Info.Printf("Net Worth (A - L): %s\n", commodity.FormatWithCode(netWorth, tree.Currency))
+	}
+	if tree.MissingRates {
+		pterm.Warning.Printf("Some balances have no %s price and are left out of the totals, add one with 'kea price add'\n", tree.Currency)
+	}
 
 	return nil
 }
+
+type accountListOutput struct {
+	Currency string          `json:"currency" yaml:"currency"`
+	Accounts []accountOutput `json:"accounts" yaml:"accounts"`
+}
+
+type accountOutput struct {
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	Currency string `json:"currency" yaml:"currency"`
+	Balance  string `json:"balance" yaml:"balance"`
+
+	// OtherBalances holds amounts in other currencies, keyed by currency.
+	OtherBalances map[string]string `json:"other_balances,omitempty" yaml:"other_balances,omitempty"`
+
+	// ConvertedBalance is in accountListOutput.Currency, nil without a price.
+	ConvertedBalance *string `json:"converted_balance" yaml:"converted_balance"`
+}
+
+func (v *AccountListView) renderStructured(balances []service.AccountBalance, currency string) error {
+	out := accountListOutput{Currency: currency, Accounts: make([]accountOutput, 0, len(balances))}
+	rows := make([][]string, 0, len(balances))
+
+	for _, b := range balances {
+		acc := b.Account
+		item := accountOutput{
+			Name:             acc.Name,
+			Type:             acc.Type,
+			Currency:         acc.Currency,
+			Balance:          commodity.Format(b.Balance, acc.Currency),
+			OtherBalances:    formatOtherBalances(b.Other),
+			ConvertedBalance: formatOptional(b.Converted, currency),
+		}
+		out.Accounts = append(out.Accounts, item)
+		rows = append(rows, []string{
+			item.Name, item.Type, item.Currency, item.Balance, csvOptional(item.ConvertedBalance), currency,
+			csvOtherBalances(item.OtherBalances),
+		})
+	}
+
+	return writeStructured(out, []string{
+		"name", "type", "currency", "balance", "converted_balance", "converted_currency", "other_balances",
+	}, rows)
+}
+
+type accountTreeOutput struct {
+	Currency string                 `json:"currency" yaml:"currency"`
+	Sections []accountSectionOutput `json:"sections" yaml:"sections"`
+}
+
+// accountSectionOutput holds the accounts of one type; totals are in
+// accountTreeOutput.Currency, balances in each account's own currency.
+type accountSectionOutput struct {
+	Title    string              `json:"title" yaml:"title"`
+	Type     string              `json:"type" yaml:"type"`
+	Total    string              `json:"total" yaml:"total"`
+	Accounts []accountNodeOutput `json:"accounts" yaml:"accounts"`
+}
+
+type accountNodeOutput struct {
+	Name          string            `json:"name" yaml:"name"`
+	Currency      string            `json:"currency" yaml:"currency"`
+	Balance       string            `json:"balance" yaml:"balance"`
+	OtherBalances map[string]string `json:"other_balances,omitempty" yaml:"other_balances,omitempty"`
+	Total         string            `json:"total" yaml:"total"`
+}
+
+func (v *AccountListView) renderTreeStructured(tree *service.AccountTree, depth int) error {
+	out := accountTreeOutput{Currency: tree.Currency, Sections: make([]accountSectionOutput, 0, len(tree.Sections))}
+	var rows [][]string
+
+	for _, section := range tree.Sections {
+		sectionOut := accountSectionOutput{
+			Title:    section.Title,
+			Type:     section.Type,
+			Total:    commodity.Format(section.Total, tree.Currency),
+			Accounts: []accountNodeOutput{},
+		}
+
+		for _, node := range section.Flatten() {
+			if depth > 0 && node.Depth+2 > depth {
+				continue
+			}
+
+			acc := node.Account
+			item := accountNodeOutput{
+				Name:          acc.Name,
+				Currency:      acc.Currency,
+				Balance:       commodity.Format(tree.Balances[acc.ID], acc.Currency),
+				OtherBalances: formatOtherBalances(tree.Other[acc.ID]),
+				Total:         commodity.Format(node.Total, tree.Currency),
+			}
+			sectionOut.Accounts = append(sectionOut.Accounts, item)
+			rows = append(rows, []string{
+				section.Title, item.Name, item.Currency, item.Balance, item.Total, tree.Currency,
+				csvOtherBalances(item.OtherBalances),
+			})
+		}
+		out.Sections = append(out.Sections, sectionOut)
+	}
+
+	return writeStructured(out, []string{
+		"section", "name", "currency", "balance", "total", "total_currency", "other_balances",
+	}, rows)
+}
+
+// formatBalance shows a balance in the account's currency followed by the
+// amounts the account holds in other currencies.
+func formatBalance(balance int64, currency string, other map[string]int64) string {
+	parts := []string{commodity.FormatWithCode(balance, currency)}
+	for _, code := range sortedCurrencies(other) {
+		parts = append(parts, commodity.FormatWithCode(other[code], code))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatOtherBalances formats amounts keyed by currency, nil when there are none.
+func formatOtherBalances(other map[string]int64) map[string]string {
+	if len(other) == 0 {
+		return nil
+	}
+	formatted := make(map[string]string, len(other))
+	for code, amount := range other {
+		formatted[code] = commodity.Format(amount, code)
+	}
+	return formatted
+}
+
+// csvOtherBalances joins formatted amounts into one cell as "amount currency" items.
+func csvOtherBalances(other map[string]string) string {
+	parts := make([]string, 0, len(other))
+	for _, code := range sortedCurrencies(other) {
+		parts = append(parts, other[code]+" "+code)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func sortedCurrencies[V any](amounts map[string]V) []string {
+	codes := make([]string, 0, len(amounts))
+	for code := range amounts {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// typeColor returns the color accounts of the given type are printed in.
+func typeColor(accType string) func(a ...interface{}) string {
+	switch accType {
+	case "A", "R": // Assets, Revenue - Green
+		return pterm.Green
+	case "L", "E": // Liabilities, Expenses - Red
+		return pterm.Red
+	case "C": // Equity - Gray
+		return pterm.Gray
+	}
+	return fmt.Sprint
+}
@@ -0,0 +1,202 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+)
+
+// RenderAccountRegister lists the splits of an account between its opening
+// and closing balance. Debits and credits are shown as stored, the balance in
+// the account's natural sign. Amounts in other currencies than the account's
+// are shown with their code.
+func RenderAccountRegister(register *service.AccountRegister) error {
+	if Structured() {
+		return renderAccountRegisterStructured(register)
+	}
+
+	currency := register.Account.Currency
+
+	title := "Register of " + register.Account.Name
+	switch {
+	case register.From != 0 && register.To != 0:
+		title += fmt.Sprintf(" %s ~ %s", utils.FormatDate(register.From), utils.FormatDate(register.To))
+	case register.From != 0:
+		title += " from " + utils.FormatDate(register.From)
+	case register.To != 0:
+		title += " until " + utils.FormatDate(register.To)
+	}
+	pterm.DefaultSection.Println(title)
+
+	tableData := pterm.TableData{
+		{"Date", "ID", "Description", "Account", "Memo", "Debit", "Credit", "Balance"},
+		{"", "", pterm.Gray("Opening Balance"), "", "", "", "", formatRegisterAmounts(register.Opening, currency)},
+	}
+
+	for _, line := range register.Lines {
+		debit, credit := splitDebitCredit(line.Amount, line.Currency, currency)
+
+		counters := strings.Join(line.Counters, ", ")
+		if counters == "" {
+			counters = "-"
+		}
+
+		tableData = append(tableData, []string{
+			utils.FormatDate(line.Timestamp),
+			fmt.Sprintf("%d", line.TransactionID),
+			line.Description,
+			counters,
+			line.Memo,
+			pterm.Green(debit),
+			pterm.Red(credit),
+			formatRegisterAmounts(line.Balance, currency),
+		})
+	}
+
+	tableData = append(tableData, []string{
+		"", "", pterm.Bold.Sprint("Closing Balance"), "", "",
+		formatRegisterAmounts(register.Debits, currency),
+		formatRegisterAmounts(register.Credits, currency),
+		pterm.Bold.Sprint(formatRegisterAmounts(register.Closing, currency)),
+	})
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	if len(register.Lines) == 0 {
+		pterm.Info.Println("No transactions in this period")
+	}
+	return nil
+}
+
+// splitDebitCredit puts an amount in stored sign into the debit or the credit
+// column, leaving the other one empty. The amount is named by its code when
+// it is not in the account's currency.
+func splitDebitCredit(amount int64, currency, accountCurrency string) (string, string) {
+	format := commodity.Format
+	if currency != accountCurrency {
+		format = commodity.FormatWithCode
+	}
+	if amount >= 0 {
+		return format(amount, currency), ""
+	}
+	return "", format(-amount, currency)
+}
+
+// formatRegisterAmounts formats amounts keyed by currency as a plain number
+// when they are only in the account's currency, and names the currencies
+// otherwise.
+func formatRegisterAmounts(amounts map[string]int64, currency string) string {
+	if len(amounts) == 0 {
+		return commodity.Format(0, currency)
+	}
+	if amount, ok := amounts[currency]; ok && len(amounts) == 1 {
+		return commodity.Format(amount, currency)
+	}
+
+	parts := make([]string, 0, len(amounts))
+	for _, code := range sortedCurrencies(amounts) {
+		parts = append(parts, commodity.FormatWithCode(amounts[code], code))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// registerAmountsOutput formats amounts keyed by currency, giving a zero in
+// the account's currency when there are none.
+func registerAmountsOutput(amounts map[string]int64, currency string) map[string]string {
+	if len(amounts) == 0 {
+		return map[string]string{currency: commodity.Format(0, currency)}
+	}
+	formatted := make(map[string]string, len(amounts))
+	for code, amount := range amounts {
+		formatted[code] = commodity.Format(amount, code)
+	}
+	return formatted
+}
+
+type accountRegisterOutput struct {
+	Account  string               `json:"account" yaml:"account"`
+	Currency string               `json:"currency" yaml:"currency"`
+	From     *string              `json:"from" yaml:"from"`
+	To       *string              `json:"to" yaml:"to"`
+	Opening  map[string]string    `json:"opening_balance" yaml:"opening_balance"`
+	Debits   map[string]string    `json:"debits" yaml:"debits"`
+	Credits  map[string]string    `json:"credits" yaml:"credits"`
+	Closing  map[string]string    `json:"closing_balance" yaml:"closing_balance"`
+	Lines    []registerLineOutput `json:"lines" yaml:"lines"`
+}
+
+type registerLineOutput struct {
+	TransactionID int64             `json:"transaction_id" yaml:"transaction_id"`
+	Date          string            `json:"date" yaml:"date"`
+	Description   string            `json:"description" yaml:"description"`
+	Accounts      []string          `json:"accounts" yaml:"accounts"`
+	Memo          string            `json:"memo" yaml:"memo"`
+	Debit         *string           `json:"debit" yaml:"debit"`
+	Credit        *string           `json:"credit" yaml:"credit"`
+	Currency      string            `json:"currency" yaml:"currency"`
+	Balance       map[string]string `json:"balance" yaml:"balance"`
+}
+
+func renderAccountRegisterStructured(register *service.AccountRegister) error {
+	currency := register.Account.Currency
+
+	optionalDate := func(timestamp int64) *string {
+		if timestamp == 0 {
+			return nil
+		}
+		date := utils.FormatDate(timestamp)
+		return &date
+	}
+
+	out := accountRegisterOutput{
+		Account:  register.Account.Name,
+		Currency: currency,
+		From:     optionalDate(register.From),
+		To:       optionalDate(register.To),
+		Opening:  registerAmountsOutput(register.Opening, currency),
+		Debits:   registerAmountsOutput(register.Debits, currency),
+		Credits:  registerAmountsOutput(register.Credits, currency),
+		Closing:  registerAmountsOutput(register.Closing, currency),
+		Lines:    make([]registerLineOutput, 0, len(register.Lines)),
+	}
+
+	rows := make([][]string, 0, len(register.Lines))
+	for _, line := range register.Lines {
+		var debit, credit *int64
+		if line.Amount >= 0 {
+			debit = &line.Amount
+		} else {
+			amount := -line.Amount
+			credit = &amount
+		}
+
+		record := registerLineOutput{
+			TransactionID: line.TransactionID,
+			Date:          utils.FormatDate(line.Timestamp),
+			Description:   line.Description,
+			Accounts:      append([]string{}, line.Counters...),
+			Memo:          line.Memo,
+			Debit:         formatOptional(debit, line.Currency),
+			Credit:        formatOptional(credit, line.Currency),
+			Currency:      line.Currency,
+			Balance:       registerAmountsOutput(line.Balance, currency),
+		}
+		out.Lines = append(out.Lines, record)
+		rows = append(rows, []string{
+			strconv.FormatInt(record.TransactionID, 10), record.Date, record.Description,
+			strings.Join(record.Accounts, "; "), record.Memo,
+			csvOptional(record.Debit), csvOptional(record.Credit), record.Currency,
+			csvOtherBalances(record.Balance),
+		})
+	}
+
+	header := []string{"transaction_id", "date", "description", "accounts", "memo", "debit", "credit", "currency", "balance"}
+	return writeStructured(out, header, rows)
+}
@@ -0,0 +1,64 @@
+package views
+
+import (
+	"github.com/hance08/kea/internal/config"
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+)
+
+// RenderAccountRenames lists renamed accounts and warns about CSV import
+// profiles that still refer to an old name.
+func RenderAccountRenames(renames []service.AccountRename, cfg *config.Config) error {
+	pterm.Success.Printf("Renamed %d account(s)\n", len(renames))
+
+	tableData := pterm.TableData{
+		{"Old Name", "New Name"},
+	}
+	oldNames := make(map[string]string, len(renames))
+	for _, r := range renames {
+		tableData = append(tableData, []string{r.OldName, r.NewName})
+		oldNames[r.OldName] = r.NewName
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	warnStaleProfiles(oldNames, cfg)
+	return nil
+}
+
+// RenderAccountRemoval reports a deleted or merged account and the
+// sub-accounts that moved with it.
+func RenderAccountRemoval(removal *service.AccountRemoval, cfg *config.Config) error {
+	if removal.Target == nil {
+		pterm.Success.Printf("Account %s deleted\n", removal.Account.Name)
+		return nil
+	}
+
+	pterm.Success.Printf("Merged %s into %s, %d split(s) moved\n",
+		removal.Account.Name, removal.Target.Name, removal.Splits)
+
+	oldNames := map[string]string{removal.Account.Name: removal.Target.Name}
+	if len(removal.Renames) > 0 {
+		if err := RenderAccountRenames(removal.Renames, cfg); err != nil {
+			return err
+		}
+	}
+
+	warnStaleProfiles(oldNames, cfg)
+	return nil
+}
+
+// warnStaleProfiles warns about CSV import profiles using a name in oldNames,
+// which maps each old name to its replacement.
+func warnStaleProfiles(oldNames map[string]string, cfg *config.Config) {
+	for name, profile := range cfg.Import.Profiles {
+		for _, account := range []string{profile.Account, profile.CounterAccount} {
+			if newName, ok := oldNames[account]; ok {
+				pterm.Warning.Printf("Import profile '%s' still uses %s, change it to %s in %s\n",
+					name, account, newName, cfg.ConfigPath)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+)
+
+type CommodityListView struct{}
+
+func NewCommodityListView() *CommodityListView {
+	return &CommodityListView{}
+}
+
+func (v *CommodityListView) Render(commodities []*model.Commodity) error {
+	if Structured() {
+		return v.renderStructured(commodities)
+	}
+
+	pterm.DefaultSection.Printf("Commodities")
+
+	tableData := pterm.TableData{
+		{"Code", "Precision", "Example"},
+	}
+	for _, c := range commodities {
+		tableData = append(tableData, []string{
+			c.Code,
+			fmt.Sprintf("%d", c.Precision),
+			utils.FormatAmount(123456789, c.Precision),
+		})
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	pterm.Info.Println("Unregistered currencies use 2 decimal places")
+	return nil
+}
+
+type commodityListOutput struct {
+	Commodities []commodityOutput `json:"commodities" yaml:"commodities"`
+}
+
+type commodityOutput struct {
+	Code      string `json:"code" yaml:"code"`
+	Precision int    `json:"precision" yaml:"precision"`
+}
+
+func (v *CommodityListView) renderStructured(commodities []*model.Commodity) error {
+	out := commodityListOutput{Commodities: make([]commodityOutput, 0, len(commodities))}
+	rows := make([][]string, 0, len(commodities))
+
+	for _, c := range commodities {
+		out.Commodities = append(out.Commodities, commodityOutput{Code: c.Code, Precision: c.Precision})
+		rows = append(rows, []string{c.Code, strconv.Itoa(c.Precision)})
+	}
+
+	return writeStructured(out, []string{"code", "precision"}, rows)
+}
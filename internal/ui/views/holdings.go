@@ -0,0 +1,337 @@
+package views
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+)
+
+// RenderHoldings shows every open position with its cost basis and market
+// value, totalled per cost currency.
+func RenderHoldings(holdings []*service.Holding) error {
+	if Structured() {
+		return renderHoldingsStructured(holdings)
+	}
+
+	if len(holdings) == 0 {
+		pterm.Warning.Println("No investment holdings, buy some with 'kea invest buy'")
+		return nil
+	}
+
+	pterm.DefaultSection.Println("Holdings")
+
+	tableData := pterm.TableData{
+		{"Account", "Quantity", "Cost Basis", "Market Value", "Unrealized Gain"},
+	}
+
+	costTotals := make(map[string]int64)
+	valueTotals := make(map[string]int64)
+	missingPrices := false
+
+	for _, h := range holdings {
+		marketValue, unrealized := "n/a", "n/a"
+		if h.MarketValue != nil {
+			marketValue = commodity.FormatWithCode(*h.MarketValue, h.CostCurrency)
+			unrealized = formatGain(*h.UnrealizedGain(), h.CostCurrency)
+			valueTotals[h.CostCurrency] += *h.MarketValue
+		} else {
+			missingPrices = true
+		}
+		costTotals[h.CostCurrency] += h.CostBasis
+
+		tableData = append(tableData, []string{
+			h.Account.Name,
+			commodity.FormatWithCode(h.Quantity, h.Account.Currency),
+			commodity.FormatWithCode(h.CostBasis, h.CostCurrency),
+			marketValue,
+			unrealized,
+		})
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	currencies := make([]string, 0, len(costTotals))
+	for currency := range costTotals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		pterm.Info.Printf("Total (%s): cost %s, market value %s\n",
+			currency,
+			commodity.FormatWithCode(costTotals[currency], currency),
+			commodity.FormatWithCode(valueTotals[currency], currency))
+	}
+
+	if missingPrices {
+		pterm.Warning.Println("Some holdings have no price and are left out of the market value, add one with 'kea price add'")
+	}
+
+	return nil
+}
+
+// RenderLots lists the open lots of the given holdings, oldest first.
+func RenderLots(holdings []*service.Holding) error {
+	if Structured() {
+		return renderLotsStructured(holdings)
+	}
+
+	if len(holdings) == 0 {
+		pterm.Warning.Println("No open lots")
+		return nil
+	}
+
+	for _, h := range holdings {
+		pterm.DefaultSection.Printf("Open Lots: %s", h.Account.Name)
+
+		tableData := pterm.TableData{
+			{"Lot", "Date", "Quantity", "Remaining", "Unit Cost", "Remaining Cost"},
+		}
+		for _, lot := range h.Lots {
+			tableData = append(tableData, []string{
+				fmt.Sprintf("#%d", lot.ID),
+				utils.FormatDate(lot.Date),
+				commodity.Format(lot.Quantity, h.Account.Currency),
+				commodity.Format(lot.Remaining, h.Account.Currency),
+				commodity.FormatWithCode(unitCost(lot, h.Account.Currency), lot.CostCurrency),
+				commodity.FormatWithCode(lot.RemainingCost, lot.CostCurrency),
+			})
+		}
+
+		if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+			return err
+		}
+
+		pterm.Info.Printf("Total: %s at a cost of %s\n",
+			commodity.FormatWithCode(h.Quantity, h.Account.Currency),
+			commodity.FormatWithCode(h.CostBasis, h.CostCurrency))
+	}
+
+	return nil
+}
+
+// RenderTrade shows the transaction of a trade and, for a sale, the lots it
+// was taken from and its realized gain.
+func RenderTrade(trade *service.Trade) error {
+	if Structured() {
+		return renderTradeStructured(trade)
+	}
+
+	if err := RenderTransactionSummary(trade.TransactionID, trade.Input); err != nil {
+		return err
+	}
+	if len(trade.Lots) == 0 {
+		return nil
+	}
+	return renderTradeLots(trade)
+}
+
+// renderTradeLots shows which lots a sale was taken from and its realized gain.
+func renderTradeLots(trade *service.Trade) error {
+	pterm.DefaultSection.Println("Lots Sold")
+
+	tableData := pterm.TableData{
+		{"Lot", "Bought", "Quantity", "Cost"},
+	}
+	for _, m := range trade.Lots {
+		tableData = append(tableData, []string{
+			fmt.Sprintf("#%d", m.Lot.ID),
+			utils.FormatDate(m.Lot.Date),
+			commodity.FormatWithCode(m.Quantity, trade.Commodity),
+			commodity.FormatWithCode(m.Cost, trade.Currency),
+		})
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	pterm.Info.Printf("Proceeds %s, cost basis %s, realized gain %s\n",
+		commodity.FormatWithCode(trade.Amount, trade.Currency),
+		commodity.FormatWithCode(trade.CostBasis, trade.Currency),
+		formatGain(trade.Gain, trade.Currency))
+
+	return nil
+}
+
+// tradeOutput gives the quantity in Commodity and the other amounts in
+// Currency; the cost basis and gain are nil for a purchase.
+type tradeOutput struct {
+	TransactionID int64                    `json:"transaction_id" yaml:"transaction_id"`
+	Commodity     string                   `json:"commodity" yaml:"commodity"`
+	Quantity      string                   `json:"quantity" yaml:"quantity"`
+	Amount        string                   `json:"amount" yaml:"amount"`
+	Currency      string                   `json:"currency" yaml:"currency"`
+	CostBasis     *string                  `json:"cost_basis" yaml:"cost_basis"`
+	Gain          *string                  `json:"gain" yaml:"gain"`
+	Lots          []tradeLotOutput         `json:"lots" yaml:"lots"`
+	Transaction   createdTransactionOutput `json:"transaction" yaml:"transaction"`
+}
+
+type tradeLotOutput struct {
+	Lot      int64  `json:"lot" yaml:"lot"`
+	Bought   string `json:"bought" yaml:"bought"`
+	Quantity string `json:"quantity" yaml:"quantity"`
+	Cost     string `json:"cost" yaml:"cost"`
+}
+
+func renderTradeStructured(trade *service.Trade) error {
+	out := tradeOutput{
+		TransactionID: trade.TransactionID,
+		Commodity:     trade.Commodity,
+		Quantity:      commodity.Format(trade.Quantity, trade.Commodity),
+		Amount:        commodity.Format(trade.Amount, trade.Currency),
+		Currency:      trade.Currency,
+		Lots:          make([]tradeLotOutput, 0, len(trade.Lots)),
+		Transaction:   newCreatedTransactionOutput(trade.TransactionID, trade.Input),
+	}
+	if len(trade.Lots) > 0 {
+		out.CostBasis = formatOptional(&trade.CostBasis, trade.Currency)
+		out.Gain = formatOptional(&trade.Gain, trade.Currency)
+	}
+
+	tradeRow := []string{
+		strconv.FormatInt(out.TransactionID, 10), out.Commodity, out.Quantity, out.Amount, out.Currency,
+		csvOptional(out.CostBasis), csvOptional(out.Gain),
+	}
+	rows := make([][]string, 0, len(trade.Lots))
+
+	for _, m := range trade.Lots {
+		lot := tradeLotOutput{
+			Lot:      m.Lot.ID,
+			Bought:   utils.FormatDate(m.Lot.Date),
+			Quantity: commodity.Format(m.Quantity, trade.Commodity),
+			Cost:     commodity.Format(m.Cost, trade.Currency),
+		}
+		out.Lots = append(out.Lots, lot)
+		rows = append(rows, slices.Concat(tradeRow, []string{
+			strconv.FormatInt(lot.Lot, 10), lot.Bought, lot.Quantity, lot.Cost,
+		}))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, slices.Concat(tradeRow, []string{"", "", "", ""}))
+	}
+
+	return writeStructured(out, []string{
+		"transaction_id", "commodity", "quantity", "amount", "currency", "cost_basis", "gain",
+		"lot", "bought", "lot_quantity", "lot_cost",
+	}, rows)
+}
+
+type holdingsOutput struct {
+	Holdings []holdingOutput `json:"holdings" yaml:"holdings"`
+}
+
+// holdingOutput gives the quantity in the account's commodity and the other
+// amounts in CostCurrency; the market value is nil without a price.
+type holdingOutput struct {
+	Account        string  `json:"account" yaml:"account"`
+	Commodity      string  `json:"commodity" yaml:"commodity"`
+	Quantity       string  `json:"quantity" yaml:"quantity"`
+	CostBasis      string  `json:"cost_basis" yaml:"cost_basis"`
+	CostCurrency   string  `json:"cost_currency" yaml:"cost_currency"`
+	MarketValue    *string `json:"market_value" yaml:"market_value"`
+	UnrealizedGain *string `json:"unrealized_gain" yaml:"unrealized_gain"`
+}
+
+func renderHoldingsStructured(holdings []*service.Holding) error {
+	out := holdingsOutput{Holdings: make([]holdingOutput, 0, len(holdings))}
+	rows := make([][]string, 0, len(holdings))
+
+	for _, h := range holdings {
+		item := holdingOutput{
+			Account:        h.Account.Name,
+			Commodity:      h.Account.Currency,
+			Quantity:       commodity.Format(h.Quantity, h.Account.Currency),
+			CostBasis:      commodity.Format(h.CostBasis, h.CostCurrency),
+			CostCurrency:   h.CostCurrency,
+			MarketValue:    formatOptional(h.MarketValue, h.CostCurrency),
+			UnrealizedGain: formatOptional(h.UnrealizedGain(), h.CostCurrency),
+		}
+		out.Holdings = append(out.Holdings, item)
+		rows = append(rows, []string{
+			item.Account, item.Commodity, item.Quantity, item.CostBasis, item.CostCurrency,
+			csvOptional(item.MarketValue), csvOptional(item.UnrealizedGain),
+		})
+	}
+
+	return writeStructured(out, []string{
+		"account", "commodity", "quantity", "cost_basis", "cost_currency", "market_value", "unrealized_gain",
+	}, rows)
+}
+
+type lotsOutput struct {
+	Lots []lotOutput `json:"lots" yaml:"lots"`
+}
+
+type lotOutput struct {
+	Account       string `json:"account" yaml:"account"`
+	LotID         int64  `json:"lot_id" yaml:"lot_id"`
+	Date          string `json:"date" yaml:"date"`
+	Commodity     string `json:"commodity" yaml:"commodity"`
+	Quantity      string `json:"quantity" yaml:"quantity"`
+	Remaining     string `json:"remaining" yaml:"remaining"`
+	UnitCost      string `json:"unit_cost" yaml:"unit_cost"`
+	RemainingCost string `json:"remaining_cost" yaml:"remaining_cost"`
+	CostCurrency  string `json:"cost_currency" yaml:"cost_currency"`
+}
+
+func renderLotsStructured(holdings []*service.Holding) error {
+	out := lotsOutput{Lots: []lotOutput{}}
+	var rows [][]string
+
+	for _, h := range holdings {
+		code := h.Account.Currency
+		for _, lot := range h.Lots {
+			item := lotOutput{
+				Account:       h.Account.Name,
+				LotID:         lot.ID,
+				Date:          utils.FormatDate(lot.Date),
+				Commodity:     code,
+				Quantity:      commodity.Format(lot.Quantity, code),
+				Remaining:     commodity.Format(lot.Remaining, code),
+				UnitCost:      commodity.Format(unitCost(lot, code), lot.CostCurrency),
+				RemainingCost: commodity.Format(lot.RemainingCost, lot.CostCurrency),
+				CostCurrency:  lot.CostCurrency,
+			}
+			out.Lots = append(out.Lots, item)
+			rows = append(rows, []string{
+				item.Account, strconv.FormatInt(item.LotID, 10), item.Date, item.Commodity,
+				item.Quantity, item.Remaining, item.UnitCost, item.RemainingCost, item.CostCurrency,
+			})
+		}
+	}
+
+	return writeStructured(out, []string{
+		"account", "lot_id", "date", "commodity", "quantity", "remaining", "unit_cost", "remaining_cost", "cost_currency",
+	}, rows)
+}
+
+// unitCost returns the cost of one unit of the lot in minor units of its
+// cost currency.
+func unitCost(lot *model.Lot, code string) int64 {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(commodity.Precision(code))), nil)
+	return utils.ApplyRate(lot.CostAmount, new(big.Rat).SetFrac(unit, big.NewInt(lot.Quantity)))
+}
+
+func formatGain(amount int64, currency string) string {
+	formatted := commodity.FormatWithCode(amount, currency)
+	switch {
+	case amount > 0:
+		return pterm.Green("+" + formatted)
+	case amount < 0:
+		return pterm.Red(formatted)
+	default:
+		return formatted
+	}
+}
@@ -0,0 +1,70 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+)
+
+func RenderImportResult(result *service.ImportResult, total int) error {
+	if Structured() {
+		return renderImportResultStructured(result, total)
+	}
+
+	pterm.DefaultSection.Println("Import Summary")
+
+	tableData := pterm.TableData{
+		{"Rows Read", fmt.Sprint(total)},
+		{"Imported", pterm.Green(fmt.Sprint(result.Imported))},
+		{"Skipped (already imported)", pterm.Gray(fmt.Sprint(result.Skipped))},
+		{"Failed", pterm.Red(fmt.Sprint(len(result.Failures)))},
+	}
+
+	if err := pterm.DefaultTable.WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	for _, failure := range result.Failures {
+		pterm.Warning.Printf("Line %d: %v\n", failure.Line, failure.Err)
+	}
+
+	if len(result.Failures) == 0 {
+		pterm.Success.Println("Import completed")
+	}
+	return nil
+}
+
+type importResultOutput struct {
+	RowsRead int                   `json:"rows_read" yaml:"rows_read"`
+	Imported int                   `json:"imported" yaml:"imported"`
+	Skipped  int                   `json:"skipped" yaml:"skipped"`
+	Failed   int                   `json:"failed" yaml:"failed"`
+	Failures []importFailureOutput `json:"failures" yaml:"failures"`
+}
+
+type importFailureOutput struct {
+	Line  int    `json:"line" yaml:"line"`
+	Error string `json:"error" yaml:"error"`
+}
+
+// renderImportResultStructured writes the counts; CSV leaves out the
+// failures, which are also listed on stderr.
+func renderImportResultStructured(result *service.ImportResult, total int) error {
+	out := importResultOutput{
+		RowsRead: total,
+		Imported: result.Imported,
+		Skipped:  result.Skipped,
+		Failed:   len(result.Failures),
+		Failures: make([]importFailureOutput, 0, len(result.Failures)),
+	}
+	for _, failure := range result.Failures {
+		out.Failures = append(out.Failures, importFailureOutput{Line: failure.Line, Error: failure.Err.Error()})
+		pterm.Warning.Printf("Line %d: %v\n", failure.Line, failure.Err)
+	}
+
+	return writeStructured(out, []string{"rows_read", "imported", "skipped", "failed"}, [][]string{{
+		strconv.Itoa(out.RowsRead), strconv.Itoa(out.Imported), strconv.Itoa(out.Skipped), strconv.Itoa(out.Failed),
+	}})
+}
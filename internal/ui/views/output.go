@@ -0,0 +1,140 @@
+package views
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/pterm/pterm"
+	"go.yaml.in/yaml/v3"
+)
+
+// Formats accepted by the global --output flag.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputCSV   = "csv"
+	OutputYAML  = "yaml"
+)
+
+var outputFormat = OutputTable
+
+// SetOutputFormat selects how views render. Any format but table writes data
+// to stdout and sends the remaining pterm messages, without colors, to stderr
+// so they do not mix with it.
+func SetOutputFormat(format string) error {
+	switch format {
+	case OutputTable:
+	case OutputJSON, OutputCSV, OutputYAML:
+		pterm.DisableStyling()
+		pterm.SetDefaultOutput(os.Stderr)
+		// Printers copy the default output when the package loads
+		for _, printer := range []*pterm.PrefixPrinter{
+			&pterm.Info, &pterm.Success, &pterm.Warning, &pterm.Error, &pterm.Debug, &pterm.Description,
+		} {
+			printer.Writer = os.Stderr
+		}
+		pterm.DefaultSection.Writer = os.Stderr
+		pterm.DefaultTable.Writer = os.Stderr
+	default:
+		return fmt.Errorf("unknown output format '%s' (must be table, json, csv or yaml)", format)
+	}
+	outputFormat = format
+	return nil
+}
+
+// Structured reports whether views write JSON, CSV or YAML instead of tables.
+func Structured() bool {
+	return outputFormat != OutputTable
+}
+
+// writeStructured writes data as JSON or YAML, or header and rows as CSV.
+// Views pass the same content both ways since CSV cannot nest.
+func writeStructured(data any, header []string, rows [][]string) error {
+	switch outputFormat {
+	case OutputJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	case OutputYAML:
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(data); err != nil {
+			return err
+		}
+		return enc.Close()
+	case OutputCSV:
+		w := csv.NewWriter(os.Stdout)
+		if err := w.Write(header); err != nil {
+			return err
+		}
+		return w.WriteAll(rows)
+	}
+	return fmt.Errorf("unknown output format '%s'", outputFormat)
+}
+
+type errorOutput struct {
+	Error errorDetail `json:"error" yaml:"error"`
+}
+
+type errorDetail struct {
+	Message string `json:"message" yaml:"message"`
+}
+
+// reportedError is a failure a view has already written to the output.
+type reportedError struct {
+	err error
+}
+
+func (e *reportedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reportedError) Unwrap() error {
+	return e.err
+}
+
+// Reported marks err as already shown by a view, e.g. the rows an import
+// failed on, so it is not written to stdout a second time.
+func Reported(err error) error {
+	return &reportedError{err: err}
+}
+
+// IsReported reports whether err was marked by Reported.
+func IsReported(err error) bool {
+	var reported *reportedError
+	return errors.As(err, &reported)
+}
+
+// RenderError reports a failed command. Structured formats get a JSON object
+// on stdout, whatever the format, so scripts can tell errors from data.
+func RenderError(message string) {
+	if !Structured() {
+		pterm.Error.Println(message)
+		return
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(errorOutput{Error: errorDetail{Message: message}})
+}
+
+// formatOptional formats an optional amount, nil when it is nil.
+func formatOptional(amount *int64, currency string) *string {
+	if amount == nil {
+		return nil
+	}
+	s := commodity.Format(*amount, currency)
+	return &s
+}
+
+// csvOptional returns s or an empty CSV cell when it is nil.
+func csvOptional(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,65 @@
+package views
+
+import (
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+)
+
+type PriceListView struct{}
+
+func NewPriceListView() *PriceListView {
+	return &PriceListView{}
+}
+
+func (v *PriceListView) Render(prices []*model.Price) error {
+	if Structured() {
+		return v.renderStructured(prices)
+	}
+
+	if len(prices) == 0 {
+		pterm.Warning.Println("No prices recorded, add one with 'kea price add'")
+		return nil
+	}
+
+	pterm.DefaultSection.Printf("Prices")
+
+	tableData := pterm.TableData{
+		{"Date", "Base", "Quote", "Rate"},
+	}
+	for _, p := range prices {
+		tableData = append(tableData, []string{utils.FormatDate(p.Date), p.Base, p.Quote, p.Rate})
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	pterm.Info.Printf("Total: %d prices\n", len(prices))
+	return nil
+}
+
+type priceListOutput struct {
+	Prices []priceOutput `json:"prices" yaml:"prices"`
+}
+
+// priceOutput is the value of one unit of Base in Quote on Date.
+type priceOutput struct {
+	Date  string `json:"date" yaml:"date"`
+	Base  string `json:"base" yaml:"base"`
+	Quote string `json:"quote" yaml:"quote"`
+	Rate  string `json:"rate" yaml:"rate"`
+}
+
+func (v *PriceListView) renderStructured(prices []*model.Price) error {
+	out := priceListOutput{Prices: make([]priceOutput, 0, len(prices))}
+	rows := make([][]string, 0, len(prices))
+
+	for _, p := range prices {
+		item := priceOutput{Date: utils.FormatDate(p.Date), Base: p.Base, Quote: p.Quote, Rate: p.Rate}
+		out.Prices = append(out.Prices, item)
+		rows = append(rows, []string{item.Date, item.Base, item.Quote, item.Rate})
+	}
+
+	return writeStructured(out, []string{"date", "base", "quote", "rate"}, rows)
+}
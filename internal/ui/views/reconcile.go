@@ -0,0 +1,104 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+)
+
+// RenderReconcileStatus shows the entries with their selection marks and the
+// running difference against the statement balance, in natural sign.
+func RenderReconcileStatus(session *service.ReconcileSession, selected map[int64]bool) error {
+	sign := service.NaturalSign(session.Account.Type)
+	currency := session.Account.Currency
+
+	tableData := pterm.TableData{
+		{"", "ID", "Date", "Description", "Amount", "Status"},
+	}
+
+	for _, entry := range session.Entries {
+		mark := "[ ]"
+		if selected[entry.SplitID] {
+			mark = pterm.Green("[x]")
+		}
+
+		status := "Cleared"
+		if entry.Status == model.StatusPending {
+			status = pterm.Yellow("Pending")
+		}
+
+		tableData = append(tableData, []string{
+			mark,
+			fmt.Sprintf("%d", entry.TransactionID),
+			utils.FormatDate(entry.Timestamp),
+			entry.Description,
+			commodity.Format(entry.Amount*sign, currency),
+			status,
+		})
+	}
+
+	pterm.DefaultSection.Printf("Reconcile %s as of %s", session.Account.Name, utils.FormatDate(session.StatementDate))
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	diff := session.Difference(selected) * sign
+	diffStr := commodity.Format(diff, currency)
+	if diff == 0 {
+		diffStr = pterm.Green(diffStr)
+	} else {
+		diffStr = pterm.Red(diffStr)
+	}
+
+	summary := pterm.TableData{
+		{"Statement Balance", commodity.Format(session.StatementBalance*sign, currency)},
+		{"Previously Reconciled", commodity.Format(session.ReconciledBalance*sign, currency)},
+		{"Difference", diffStr},
+	}
+	pterm.Println()
+	return pterm.DefaultTable.WithData(summary).Render()
+}
+
+// RenderReconcileResult reports a completed reconciliation.
+func RenderReconcileResult(session *service.ReconcileSession, selected map[int64]bool) error {
+	if !Structured() {
+		pterm.Success.Printf("%s reconciled as of %s\n", session.Account.Name, utils.FormatDate(session.StatementDate))
+		return nil
+	}
+
+	out := reconcileResultOutput{
+		Account:          session.Account.Name,
+		StatementDate:    utils.FormatDate(session.StatementDate),
+		StatementBalance: commodity.Format(session.StatementBalance*service.NaturalSign(session.Account.Type), session.Account.Currency),
+		Currency:         session.Account.Currency,
+		TransactionIDs:   []int64{},
+	}
+	seen := make(map[int64]bool)
+	for _, entry := range session.Entries {
+		if selected[entry.SplitID] && !seen[entry.TransactionID] {
+			seen[entry.TransactionID] = true
+			out.TransactionIDs = append(out.TransactionIDs, entry.TransactionID)
+		}
+	}
+	out.Reconciled = len(out.TransactionIDs)
+
+	return writeStructured(out, []string{"account", "statement_date", "statement_balance", "currency", "reconciled"}, [][]string{{
+		out.Account, out.StatementDate, out.StatementBalance, out.Currency, strconv.Itoa(out.Reconciled),
+	}})
+}
+
+// reconcileResultOutput gives the statement balance in the account's
+// natural sign and the transactions that were marked reconciled.
+type reconcileResultOutput struct {
+	Account          string  `json:"account" yaml:"account"`
+	StatementDate    string  `json:"statement_date" yaml:"statement_date"`
+	StatementBalance string  `json:"statement_balance" yaml:"statement_balance"`
+	Currency         string  `json:"currency" yaml:"currency"`
+	Reconciled       int     `json:"reconciled" yaml:"reconciled"`
+	TransactionIDs   []int64 `json:"transaction_ids" yaml:"transaction_ids"`
+}
@@ -0,0 +1,182 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/ui"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+)
+
+func RenderBalanceSheet(sheet *service.BalanceSheet) error {
+	if Structured() {
+		return renderBalanceSheetStructured(sheet)
+	}
+
+	date := utils.FormatDate(sheet.Date)
+	pterm.DefaultSection.Printf("Balance Sheet as of %s", date)
+
+	for _, section := range []service.ReportSection{sheet.Assets, sheet.Liabilities} {
+		if err := renderReportSection(section, sheet.Currency); err != nil {
+			return err
+		}
+	}
+
+	extra := []reportRow{{Label: "Current Earnings (R - E)", Amount: sheet.CurrentEarnings}}
+	if sheet.Translation != 0 {
+		extra = append(extra, reportRow{Label: "Currency Translation", Amount: sheet.Translation})
+	}
+	if err := renderReportSection(sheet.Equity, sheet.Currency, extra...); err != nil {
+		return err
+	}
+
+	pterm.Println()
+	summaryData := pterm.TableData{
+		{"Total Assets", commodity.Format(sheet.Assets.Total, sheet.Currency)},
+		{"Total Liabilities", commodity.Format(sheet.Liabilities.Total, sheet.Currency)},
+		{"Total Equity", commodity.Format(sheet.Equity.Total+sheet.CurrentEarnings+sheet.Translation, sheet.Currency)},
+	}
+	if err := pterm.DefaultTable.WithData(summaryData).Render(); err != nil {
+		return err
+	}
+
+	if sheet.Difference == 0 {
+		pterm.Success.Println("Balanced: A = L + C + (R - E)")
+	} else {
+		pterm.Warning.Printf("Out of balance: A - (L + C + (R - E)) = %s\n",
+			commodity.FormatWithCode(sheet.Difference, sheet.Currency))
+	}
+
+	return nil
+}
+
+// reportRow is a synthetic line that is not backed by an account.
+type reportRow struct {
+	Label  string
+	Amount int64
+}
+
+// renderReportSection prints one section as an indented table. Extra rows are
+// appended before the section total and included in it.
+func renderReportSection(section service.ReportSection, currency string, extra ...reportRow) error {
+	pterm.Println()
+	ui.PrintL2Title("%s", section.Title)
+
+	tableData := pterm.TableData{
+		{"Account", "Amount (" + currency + ")"},
+	}
+
+	for _, node := range section.Flatten() {
+		tableData = append(tableData, []string{treeLabel(node), commodity.Format(node.Total, currency)})
+	}
+
+	total := section.Total
+	for _, row := range extra {
+		tableData = append(tableData, []string{row.Label, commodity.Format(row.Amount, currency)})
+		total += row.Amount
+	}
+
+	tableData = append(tableData, []string{
+		pterm.Bold.Sprint("Total " + section.Title),
+		pterm.Bold.Sprint(commodity.Format(total, currency)),
+	})
+
+	return pterm.DefaultTable.
+		WithHasHeader().
+		WithHeaderStyle(pterm.NewStyle(pterm.FgGray)).
+		WithData(tableData).
+		Render()
+}
+
+type balanceSheetOutput struct {
+	Date            string              `json:"date" yaml:"date"`
+	Currency        string              `json:"currency" yaml:"currency"`
+	Assets          reportSectionOutput `json:"assets" yaml:"assets"`
+	Liabilities     reportSectionOutput `json:"liabilities" yaml:"liabilities"`
+	Equity          reportSectionOutput `json:"equity" yaml:"equity"`
+	CurrentEarnings string              `json:"current_earnings" yaml:"current_earnings"`
+	Translation     string              `json:"translation" yaml:"translation"`
+	Difference      string              `json:"difference" yaml:"difference"`
+}
+
+func renderBalanceSheetStructured(sheet *service.BalanceSheet) error {
+	out := balanceSheetOutput{
+		Date:            utils.FormatDate(sheet.Date),
+		Currency:        sheet.Currency,
+		Assets:          newReportSectionOutput(sheet.Assets, sheet.Currency),
+		Liabilities:     newReportSectionOutput(sheet.Liabilities, sheet.Currency),
+		Equity:          newReportSectionOutput(sheet.Equity, sheet.Currency),
+		CurrentEarnings: commodity.Format(sheet.CurrentEarnings, sheet.Currency),
+		Translation:     commodity.Format(sheet.Translation, sheet.Currency),
+		Difference:      commodity.Format(sheet.Difference, sheet.Currency),
+	}
+
+	var rows [][]string
+	for _, section := range []reportSectionOutput{out.Assets, out.Liabilities, out.Equity} {
+		rows = append(rows, section.csvRows()...)
+	}
+	rows = append(rows,
+		[]string{out.Equity.Title, "Current Earnings", "", out.CurrentEarnings},
+		[]string{out.Equity.Title, "Currency Translation", "", out.Translation},
+	)
+
+	return writeStructured(out, reportSectionHeader, rows)
+}
+
+// reportSectionOutput is one section of a report with natural signs, in the
+// report currency.
+type reportSectionOutput struct {
+	Title    string             `json:"title" yaml:"title"`
+	Total    string             `json:"total" yaml:"total"`
+	Accounts []reportLineOutput `json:"accounts" yaml:"accounts"`
+}
+
+type reportLineOutput struct {
+	Name    string `json:"name" yaml:"name"`
+	Balance string `json:"balance" yaml:"balance"` // own splits only
+	Total   string `json:"total" yaml:"total"`     // including sub-accounts
+}
+
+var reportSectionHeader = []string{"section", "account", "balance", "total"}
+
+func newReportSectionOutput(section service.ReportSection, currency string) reportSectionOutput {
+	out := reportSectionOutput{
+		Title:    section.Title,
+		Total:    commodity.Format(section.Total, currency),
+		Accounts: []reportLineOutput{},
+	}
+	for _, node := range section.Flatten() {
+		out.Accounts = append(out.Accounts, reportLineOutput{
+			Name:    node.Account.Name,
+			Balance: commodity.Format(node.Balance, currency),
+			Total:   commodity.Format(node.Total, currency),
+		})
+	}
+	return out
+}
+
+func (s reportSectionOutput) csvRows() [][]string {
+	rows := make([][]string, 0, len(s.Accounts))
+	for _, line := range s.Accounts {
+		rows = append(rows, []string{s.Title, line.Name, line.Balance, line.Total})
+	}
+	return rows
+}
+
+// treeLabel indents a node by depth; nested nodes show only their last name segment.
+func treeLabel(node *service.AccountNode) string {
+	name := node.Account.Name
+	if node.Depth > 0 {
+		name = lastSegment(name)
+	}
+	return strings.Repeat("  ", node.Depth) + name
+}
+
+func lastSegment(name string) string {
+	if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		return name[idx+1:]
+	}
+	return name
+}
@@ -0,0 +1,57 @@
+package views
+
+import (
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+)
+
+func RenderIncomeStatement(statement *service.IncomeStatement) error {
+	if Structured() {
+		return renderIncomeStatementStructured(statement)
+	}
+
+	from := utils.FormatDate(statement.From)
+	to := utils.FormatDate(statement.To)
+	pterm.DefaultSection.Printf("Income Statement %s ~ %s", from, to)
+
+	for _, section := range []service.ReportSection{statement.Revenue, statement.Expenses} {
+		if err := renderReportSection(section, statement.Currency); err != nil {
+			return err
+		}
+	}
+
+	pterm.Println()
+	netIncome := commodity.FormatWithCode(statement.NetIncome, statement.Currency)
+	if statement.NetIncome >= 0 {
+		pterm.Success.Printf("Net Income: %s\n", netIncome)
+	} else {
+		pterm.Warning.Printf("Net Loss: %s\n", netIncome)
+	}
+
+	return nil
+}
+
+type incomeStatementOutput struct {
+	From      string              `json:"from" yaml:"from"`
+	To        string              `json:"to" yaml:"to"`
+	Currency  string              `json:"currency" yaml:"currency"`
+	Revenue   reportSectionOutput `json:"revenue" yaml:"revenue"`
+	Expenses  reportSectionOutput `json:"expenses" yaml:"expenses"`
+	NetIncome string              `json:"net_income" yaml:"net_income"`
+}
+
+func renderIncomeStatementStructured(statement *service.IncomeStatement) error {
+	out := incomeStatementOutput{
+		From:      utils.FormatDate(statement.From),
+		To:        utils.FormatDate(statement.To),
+		Currency:  statement.Currency,
+		Revenue:   newReportSectionOutput(statement.Revenue, statement.Currency),
+		Expenses:  newReportSectionOutput(statement.Expenses, statement.Currency),
+		NetIncome: commodity.Format(statement.NetIncome, statement.Currency),
+	}
+
+	rows := append(out.Revenue.csvRows(), out.Expenses.csvRows()...)
+	return writeStructured(out, reportSectionHeader, rows)
+}
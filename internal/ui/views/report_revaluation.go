@@ -0,0 +1,92 @@
+package views
+
+import (
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+)
+
+// RenderRevaluation shows the book value and closing value of every
+// foreign-currency account. Balances use natural signs.
+func RenderRevaluation(rev *service.Revaluation) error {
+	if Structured() {
+		return renderRevaluationStructured(rev)
+	}
+
+	pterm.DefaultSection.Printf("Currency Revaluation as of %s", utils.FormatDate(rev.Date))
+
+	if len(rev.Lines) == 0 {
+		pterm.Info.Printf("No asset or liability accounts held in a currency other than %s\n", rev.Currency)
+		return nil
+	}
+
+	tableData := pterm.TableData{
+		{"Account", "Balance", "Rate", "Book Value (" + rev.Currency + ")", "Value (" + rev.Currency + ")", "Unrealized Gain"},
+	}
+
+	for _, line := range rev.Lines {
+		sign := service.NaturalSign(line.Account.Type)
+		tableData = append(tableData, []string{
+			line.Account.Name,
+			commodity.FormatWithCode(line.Balance*sign, line.Account.Currency),
+			utils.FormatRate(line.Rate),
+			commodity.Format(line.BookValue*sign, rev.Currency),
+			commodity.Format(line.Value*sign, rev.Currency),
+			formatGain(line.Gain, rev.Currency),
+		})
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	pterm.Info.Printf("Total unrealized gain: %s\n", formatGain(rev.Total, rev.Currency))
+	return nil
+}
+
+type revaluationOutput struct {
+	Date      string                  `json:"date" yaml:"date"`
+	Currency  string                  `json:"currency" yaml:"currency"`
+	Accounts  []revaluationLineOutput `json:"accounts" yaml:"accounts"`
+	TotalGain string                  `json:"total_gain" yaml:"total_gain"`
+}
+
+// revaluationLineOutput gives the balance in the account's currency and the
+// values in revaluationOutput.Currency.
+type revaluationLineOutput struct {
+	Account   string `json:"account" yaml:"account"`
+	Currency  string `json:"currency" yaml:"currency"`
+	Balance   string `json:"balance" yaml:"balance"`
+	Rate      string `json:"rate" yaml:"rate"`
+	BookValue string `json:"book_value" yaml:"book_value"`
+	Value     string `json:"value" yaml:"value"`
+	Gain      string `json:"gain" yaml:"gain"`
+}
+
+func renderRevaluationStructured(rev *service.Revaluation) error {
+	out := revaluationOutput{
+		Date:      utils.FormatDate(rev.Date),
+		Currency:  rev.Currency,
+		Accounts:  make([]revaluationLineOutput, 0, len(rev.Lines)),
+		TotalGain: commodity.Format(rev.Total, rev.Currency),
+	}
+	rows := make([][]string, 0, len(rev.Lines))
+
+	for _, line := range rev.Lines {
+		sign := service.NaturalSign(line.Account.Type)
+		item := revaluationLineOutput{
+			Account:   line.Account.Name,
+			Currency:  line.Account.Currency,
+			Balance:   commodity.Format(line.Balance*sign, line.Account.Currency),
+			Rate:      utils.FormatRate(line.Rate),
+			BookValue: commodity.Format(line.BookValue*sign, rev.Currency),
+			Value:     commodity.Format(line.Value*sign, rev.Currency),
+			Gain:      commodity.Format(line.Gain, rev.Currency),
+		}
+		out.Accounts = append(out.Accounts, item)
+		rows = append(rows, []string{item.Account, item.Currency, item.Balance, item.Rate, item.BookValue, item.Value, item.Gain, rev.Currency})
+	}
+
+	return writeStructured(out, []string{"account", "currency", "balance", "rate", "book_value", "value", "gain", "value_currency"}, rows)
+}
@@ -0,0 +1,129 @@
+package views
+
+import (
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+)
+
+type TrendReportView struct{}
+
+func NewTrendReportView() *TrendReportView {
+	return &TrendReportView{}
+}
+
+func (v *TrendReportView) Render(report *service.TrendReport, title string) error {
+	if Structured() {
+		return v.renderStructured(report)
+	}
+
+	pterm.DefaultSection.Printf("%s trend by %s (%s)", title, report.Period, report.Currency)
+
+	if len(report.Rows) == 0 {
+		pterm.Warning.Println("No activity found in this period")
+		return nil
+	}
+
+	header := []string{"Account"}
+	header = append(header, report.Periods...)
+	header = append(header, "Total", "Average")
+	tableData := pterm.TableData{header}
+
+	for _, row := range report.Rows {
+		line := []string{row.AccountName}
+		for _, amount := range row.Amounts {
+			line = append(line, formatTrendCell(amount, report.Currency))
+		}
+		line = append(line, commodity.Format(row.Total, report.Currency), commodity.Format(row.Average, report.Currency))
+		tableData = append(tableData, line)
+	}
+
+	footer := []string{pterm.Bold.Sprint("Total")}
+	for _, amount := range report.ColumnTotals {
+		footer = append(footer, pterm.Bold.Sprint(commodity.Format(amount, report.Currency)))
+	}
+	footer = append(footer,
+		pterm.Bold.Sprint(commodity.Format(report.GrandTotal, report.Currency)),
+		pterm.Bold.Sprint(commodity.Format(report.Average, report.Currency)),
+	)
+	tableData = append(tableData, footer)
+
+	if err := pterm.DefaultTable.
+		WithHasHeader().
+		WithHeaderStyle(pterm.NewStyle(pterm.FgGray)).
+		WithRightAlignment().
+		WithData(tableData).
+		Render(); err != nil {
+		return err
+	}
+
+	pterm.Info.Printf("Total: %d accounts over %d periods\n", len(report.Rows), len(report.Periods))
+	return nil
+}
+
+type trendReportOutput struct {
+	Type         string           `json:"type" yaml:"type"`
+	Period       string           `json:"period" yaml:"period"`
+	Currency     string           `json:"currency" yaml:"currency"`
+	Periods      []string         `json:"periods" yaml:"periods"`
+	Accounts     []trendRowOutput `json:"accounts" yaml:"accounts"`
+	ColumnTotals []string         `json:"column_totals" yaml:"column_totals"`
+	Total        string           `json:"total" yaml:"total"`
+	Average      string           `json:"average" yaml:"average"`
+}
+
+// trendRowOutput holds one account's amounts, aligned with
+// trendReportOutput.Periods.
+type trendRowOutput struct {
+	Account string   `json:"account" yaml:"account"`
+	Amounts []string `json:"amounts" yaml:"amounts"`
+	Total   string   `json:"total" yaml:"total"`
+	Average string   `json:"average" yaml:"average"`
+}
+
+// renderStructured writes one CSV column per period, closed by a "Total" row.
+func (v *TrendReportView) renderStructured(report *service.TrendReport) error {
+	format := func(amounts []int64) []string {
+		formatted := make([]string, len(amounts))
+		for i, amount := range amounts {
+			formatted[i] = commodity.Format(amount, report.Currency)
+		}
+		return formatted
+	}
+
+	out := trendReportOutput{
+		Type:         report.Type,
+		Period:       report.Period,
+		Currency:     report.Currency,
+		Periods:      append([]string{}, report.Periods...),
+		Accounts:     make([]trendRowOutput, 0, len(report.Rows)),
+		ColumnTotals: format(report.ColumnTotals),
+		Total:        commodity.Format(report.GrandTotal, report.Currency),
+		Average:      commodity.Format(report.Average, report.Currency),
+	}
+
+	header := append(append([]string{"account"}, report.Periods...), "total", "average")
+	rows := make([][]string, 0, len(report.Rows)+1)
+
+	for _, row := range report.Rows {
+		item := trendRowOutput{
+			Account: row.AccountName,
+			Amounts: format(row.Amounts),
+			Total:   commodity.Format(row.Total, report.Currency),
+			Average: commodity.Format(row.Average, report.Currency),
+		}
+		out.Accounts = append(out.Accounts, item)
+		rows = append(rows, append(append([]string{item.Account}, item.Amounts...), item.Total, item.Average))
+	}
+	rows = append(rows, append(append([]string{"Total"}, out.ColumnTotals...), out.Total, out.Average))
+
+	return writeStructured(out, header, rows)
+}
+
+// formatTrendCell keeps empty periods visually quiet.
+func formatTrendCell(amount int64, currency string) string {
+	if amount == 0 {
+		return pterm.Gray("-")
+	}
+	return commodity.Format(amount, currency)
+}
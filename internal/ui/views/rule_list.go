@@ -0,0 +1,117 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/service"
+	"github.com/pterm/pterm"
+)
+
+type RuleListView struct{}
+
+func NewRuleListView() *RuleListView {
+	return &RuleListView{}
+}
+
+func (v *RuleListView) Render(rules []*service.RuleDetail) error {
+	if Structured() {
+		return v.renderStructured(rules)
+	}
+
+	if len(rules) == 0 {
+		pterm.Warning.Println("No rules defined, unmatched transactions go to Expenses:Uncategorized")
+		return nil
+	}
+
+	pterm.DefaultSection.Printf("Categorization Rules")
+
+	tableData := pterm.TableData{
+		{"ID", "Priority", "Pattern", "Amount", "Source", "Target"},
+	}
+
+	for _, rule := range rules {
+		pattern := rule.DescriptionPattern
+		if pattern == "" {
+			pattern = "-"
+		}
+		source := rule.SourceAccount
+		if source == "" {
+			source = "-"
+		}
+
+		tableData = append(tableData, []string{
+			fmt.Sprintf("%d", rule.ID),
+			fmt.Sprintf("%d", rule.Priority),
+			pattern,
+			formatAmountRange(rule.MinAmount, rule.MaxAmount, rule.Currency),
+			source,
+			pterm.Green(rule.TargetAccount),
+		})
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	pterm.Info.Printf("Total: %d rules\n", len(rules))
+	return nil
+}
+
+type ruleListOutput struct {
+	Rules []ruleOutput `json:"rules" yaml:"rules"`
+}
+
+// ruleOutput leaves unset conditions empty; amounts are in Currency.
+type ruleOutput struct {
+	ID            int64   `json:"id" yaml:"id"`
+	Priority      int     `json:"priority" yaml:"priority"`
+	Pattern       string  `json:"pattern" yaml:"pattern"`
+	MinAmount     *string `json:"min_amount" yaml:"min_amount"`
+	MaxAmount     *string `json:"max_amount" yaml:"max_amount"`
+	Currency      string  `json:"currency" yaml:"currency"`
+	SourceAccount string  `json:"source_account" yaml:"source_account"`
+	TargetAccount string  `json:"target_account" yaml:"target_account"`
+}
+
+func (v *RuleListView) renderStructured(rules []*service.RuleDetail) error {
+	out := ruleListOutput{Rules: make([]ruleOutput, 0, len(rules))}
+	rows := make([][]string, 0, len(rules))
+
+	for _, rule := range rules {
+		item := ruleOutput{
+			ID:            rule.ID,
+			Priority:      rule.Priority,
+			Pattern:       rule.DescriptionPattern,
+			MinAmount:     formatOptional(rule.MinAmount, rule.Currency),
+			MaxAmount:     formatOptional(rule.MaxAmount, rule.Currency),
+			Currency:      rule.Currency,
+			SourceAccount: rule.SourceAccount,
+			TargetAccount: rule.TargetAccount,
+		}
+		out.Rules = append(out.Rules, item)
+		rows = append(rows, []string{
+			strconv.FormatInt(item.ID, 10), strconv.Itoa(item.Priority), item.Pattern,
+			csvOptional(item.MinAmount), csvOptional(item.MaxAmount), item.Currency,
+			item.SourceAccount, item.TargetAccount,
+		})
+	}
+
+	return writeStructured(out, []string{
+		"id", "priority", "pattern", "min_amount", "max_amount", "currency", "source_account", "target_account",
+	}, rows)
+}
+
+func formatAmountRange(minAmount, maxAmount *int64, currency string) string {
+	switch {
+	case minAmount != nil && maxAmount != nil:
+		return fmt.Sprintf("%s ~ %s", commodity.Format(*minAmount, currency), commodity.Format(*maxAmount, currency))
+	case minAmount != nil:
+		return ">= " + commodity.Format(*minAmount, currency)
+	case maxAmount != nil:
+		return "<= " + commodity.Format(*maxAmount, currency)
+	default:
+		return "-"
+	}
+}
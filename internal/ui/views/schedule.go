@@ -0,0 +1,299 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
+	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
+	"github.com/pterm/pterm"
+)
+
+// RenderScheduleList shows the scheduled transactions with the date each one
+// posts next.
+func RenderScheduleList(schedules []*service.ScheduleDetail) error {
+	if Structured() {
+		return renderScheduleListStructured(schedules)
+	}
+
+	if len(schedules) == 0 {
+		pterm.Info.Println("No scheduled transactions, add one with 'kea schedule add'")
+		return nil
+	}
+
+	pterm.DefaultSection.Println("Scheduled Transactions")
+
+	tableData := pterm.TableData{
+		{"ID", "Description", "Recurrence", "From", "To", "Amount", "Mode", "Posted", "Next"},
+	}
+
+	for _, schedule := range schedules {
+		from, to, amount := summarizeSplits(schedule.Splits)
+
+		mode := "Post"
+		if !schedule.AutoPost {
+			mode = pterm.Yellow("Pending")
+		}
+
+		next := pterm.Gray("ended")
+		if schedule.Next != 0 {
+			next = utils.FormatDate(schedule.Next)
+		}
+
+		tableData = append(tableData, []string{
+			fmt.Sprintf("%d", schedule.ID),
+			schedule.Description,
+			describeRecurrence(schedule.ScheduledTransaction),
+			from,
+			to,
+			amount,
+			mode,
+			fmt.Sprintf("%d", schedule.Posted),
+			next,
+		})
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	pterm.Info.Printf("Total: %d scheduled transactions\n", len(schedules))
+	return nil
+}
+
+// RenderScheduleRun lists the transactions posted by 'kea schedule run'.
+func RenderScheduleRun(postings []service.ScheduledPosting, until int64) error {
+	if Structured() {
+		return renderScheduleRunStructured(postings)
+	}
+
+	if len(postings) == 0 {
+		pterm.Info.Printf("Nothing due until %s\n", utils.FormatDate(until))
+		return nil
+	}
+
+	tableData := pterm.TableData{
+		{"Schedule", "Transaction", "Date", "Description", "From", "To", "Amount", "Status"},
+	}
+
+	for _, posting := range postings {
+		from, to, amount := summarizeSplits(posting.Splits)
+
+		status := "Cleared"
+		if posting.Status == model.StatusPending {
+			status = pterm.Yellow("Pending")
+		}
+
+		tableData = append(tableData, []string{
+			fmt.Sprintf("#%d", posting.ScheduleID),
+			fmt.Sprintf("%d", posting.TransactionID),
+			utils.FormatDate(posting.Timestamp),
+			posting.Description,
+			from,
+			to,
+			amount,
+			status,
+		})
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Posted %d scheduled transactions until %s\n", len(postings), utils.FormatDate(until))
+	return nil
+}
+
+// describeRecurrence spells out a recurrence, e.g. "every 2 months on day 31".
+func describeRecurrence(schedule model.ScheduledTransaction) string {
+	units := map[string]string{
+		model.FrequencyDaily:   "day",
+		model.FrequencyWeekly:  "week",
+		model.FrequencyMonthly: "month",
+		model.FrequencyYearly:  "year",
+	}
+
+	text := "every " + units[schedule.Frequency]
+	if schedule.Interval > 1 {
+		text = fmt.Sprintf("every %d %ss", schedule.Interval, units[schedule.Frequency])
+	}
+	if schedule.DayOfMonth != nil {
+		text += fmt.Sprintf(" on day %d", *schedule.DayOfMonth)
+	}
+	if schedule.EndDate != nil {
+		text += " until " + utils.FormatDate(*schedule.EndDate)
+	}
+	return text
+}
+
+// summarizeSplits names the credited and the debited accounts of a
+// transaction, and the amount moved when it is in a single currency.
+func summarizeSplits(splits []service.TransactionSplitInput) (string, string, string) {
+	var from, to []string
+	totals := make(map[string]int64)
+	for _, split := range splits {
+		if split.Amount < 0 {
+			from = append(from, split.AccountName)
+		} else {
+			to = append(to, split.AccountName)
+			totals[split.Currency] += split.Amount
+		}
+	}
+
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	amounts := make([]string, 0, len(currencies))
+	for _, currency := range currencies {
+		amounts = append(amounts, commodity.FormatWithCode(totals[currency], currency))
+	}
+
+	return strings.Join(from, ", "), strings.Join(to, ", "), strings.Join(amounts, ", ")
+}
+
+type scheduleListOutput struct {
+	Schedules []scheduleOutput `json:"schedules" yaml:"schedules"`
+}
+
+type scheduleOutput struct {
+	ID          int64                 `json:"id" yaml:"id"`
+	Description string                `json:"description" yaml:"description"`
+	Frequency   string                `json:"frequency" yaml:"frequency"`
+	Interval    int                   `json:"interval" yaml:"interval"`
+	DayOfMonth  *int                  `json:"day_of_month" yaml:"day_of_month"`
+	StartDate   string                `json:"start_date" yaml:"start_date"`
+	EndDate     *string               `json:"end_date" yaml:"end_date"`
+	AutoPost    bool                  `json:"auto_post" yaml:"auto_post"`
+	Posted      int                   `json:"posted" yaml:"posted"`
+	LastPosted  *string               `json:"last_posted" yaml:"last_posted"`
+	Next        *string               `json:"next" yaml:"next"`
+	Splits      []scheduleSplitOutput `json:"splits" yaml:"splits"`
+}
+
+type scheduleSplitOutput struct {
+	Account  string `json:"account" yaml:"account"`
+	Amount   string `json:"amount" yaml:"amount"`
+	Currency string `json:"currency" yaml:"currency"`
+	Memo     string `json:"memo" yaml:"memo"`
+}
+
+func renderScheduleListStructured(schedules []*service.ScheduleDetail) error {
+	out := scheduleListOutput{Schedules: make([]scheduleOutput, 0, len(schedules))}
+	rows := make([][]string, 0, len(schedules))
+
+	for _, schedule := range schedules {
+		item := scheduleOutput{
+			ID:          schedule.ID,
+			Description: schedule.Description,
+			Frequency:   schedule.Frequency,
+			Interval:    schedule.Interval,
+			DayOfMonth:  schedule.DayOfMonth,
+			StartDate:   utils.FormatDate(schedule.StartDate),
+			AutoPost:    schedule.AutoPost,
+			Posted:      schedule.Posted,
+			LastPosted:  optionalScheduleDate(schedule.LastPosted),
+			Next:        optionalScheduleDate(schedule.Next),
+			Splits:      scheduleSplitOutputs(schedule.Splits),
+		}
+		if schedule.EndDate != nil {
+			item.EndDate = optionalScheduleDate(*schedule.EndDate)
+		}
+		out.Schedules = append(out.Schedules, item)
+
+		dayOfMonth := ""
+		if item.DayOfMonth != nil {
+			dayOfMonth = strconv.Itoa(*item.DayOfMonth)
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(item.ID, 10), item.Description, item.Frequency,
+			strconv.Itoa(item.Interval), dayOfMonth, item.StartDate, csvOptional(item.EndDate),
+			strconv.FormatBool(item.AutoPost), strconv.Itoa(item.Posted),
+			csvOptional(item.LastPosted), csvOptional(item.Next), csvSplits(item.Splits),
+		})
+	}
+
+	return writeStructured(out, []string{
+		"id", "description", "frequency", "interval", "day_of_month", "start_date", "end_date",
+		"auto_post", "posted", "last_posted", "next", "splits",
+	}, rows)
+}
+
+type scheduleRunOutput struct {
+	Posted []scheduledPostingOutput `json:"posted" yaml:"posted"`
+}
+
+type scheduledPostingOutput struct {
+	ScheduleID    int64                 `json:"schedule_id" yaml:"schedule_id"`
+	TransactionID int64                 `json:"transaction_id" yaml:"transaction_id"`
+	Date          string                `json:"date" yaml:"date"`
+	Description   string                `json:"description" yaml:"description"`
+	Status        string                `json:"status" yaml:"status"`
+	Splits        []scheduleSplitOutput `json:"splits" yaml:"splits"`
+}
+
+func renderScheduleRunStructured(postings []service.ScheduledPosting) error {
+	out := scheduleRunOutput{Posted: make([]scheduledPostingOutput, 0, len(postings))}
+	rows := make([][]string, 0, len(postings))
+
+	for _, posting := range postings {
+		status := "cleared"
+		if posting.Status == model.StatusPending {
+			status = "pending"
+		}
+
+		item := scheduledPostingOutput{
+			ScheduleID:    posting.ScheduleID,
+			TransactionID: posting.TransactionID,
+			Date:          utils.FormatDate(posting.Timestamp),
+			Description:   posting.Description,
+			Status:        status,
+			Splits:        scheduleSplitOutputs(posting.Splits),
+		}
+		out.Posted = append(out.Posted, item)
+		rows = append(rows, []string{
+			strconv.FormatInt(item.ScheduleID, 10), strconv.FormatInt(item.TransactionID, 10),
+			item.Date, item.Description, item.Status, csvSplits(item.Splits),
+		})
+	}
+
+	return writeStructured(out, []string{
+		"schedule_id", "transaction_id", "date", "description", "status", "splits",
+	}, rows)
+}
+
+func scheduleSplitOutputs(splits []service.TransactionSplitInput) []scheduleSplitOutput {
+	result := make([]scheduleSplitOutput, 0, len(splits))
+	for _, split := range splits {
+		result = append(result, scheduleSplitOutput{
+			Account:  split.AccountName,
+			Amount:   commodity.Format(split.Amount, split.Currency),
+			Currency: split.Currency,
+			Memo:     split.Memo,
+		})
+	}
+	return result
+}
+
+// csvSplits joins splits into one cell as "account amount currency" items.
+func csvSplits(splits []scheduleSplitOutput) string {
+	parts := make([]string, 0, len(splits))
+	for _, split := range splits {
+		parts = append(parts, fmt.Sprintf("%s %s %s", split.Account, split.Amount, split.Currency))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func optionalScheduleDate(timestamp int64) *string {
+	if timestamp == 0 {
+		return nil
+	}
+	date := utils.FormatDate(timestamp)
+	return &date
+}
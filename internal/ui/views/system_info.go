@@ -1,6 +1,10 @@
 package views
 
-import "github.com/pterm/pterm"
+import (
+	"strconv"
+
+	"github.com/pterm/pterm"
+)
 
 type SystemInfoItem struct {
 	ConfigPath      string
@@ -11,6 +15,10 @@ type SystemInfoItem struct {
 }
 
 func RenderSystemInfo(data SystemInfoItem) error {
+	if Structured() {
+		return renderSystemInfoStructured(data)
+	}
+
 	dbStatus := pterm.Green("Found")
 	if !data.DBExists {
 		dbStatus = pterm.Red("Not Found (Will be created)")
@@ -26,3 +34,18 @@ func RenderSystemInfo(data SystemInfoItem) error {
 
 	return pterm.DefaultTable.WithData(tableData).Render()
 }
+
+type systemInfoOutput struct {
+	ConfigPath      string `json:"config_path" yaml:"config_path"`
+	DBPath          string `json:"db_path" yaml:"db_path"`
+	DBExists        bool   `json:"db_exists" yaml:"db_exists"`
+	DefaultCurrency string `json:"default_currency" yaml:"default_currency"`
+	AppDataDir      string `json:"app_data_dir" yaml:"app_data_dir"`
+}
+
+func renderSystemInfoStructured(data SystemInfoItem) error {
+	out := systemInfoOutput(data)
+	return writeStructured(out,
+		[]string{"config_path", "db_path", "db_exists", "default_currency", "app_data_dir"},
+		[][]string{{out.ConfigPath, out.DBPath, strconv.FormatBool(out.DBExists), out.DefaultCurrency, out.AppDataDir}})
+}
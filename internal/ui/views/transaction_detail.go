@@ -2,8 +2,11 @@ package views
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
 	"github.com/hance08/kea/internal/service"
 	"github.com/hance08/kea/internal/ui"
 	"github.com/hance08/kea/internal/utils"
@@ -13,8 +16,15 @@ import (
 func RenderTransactionDetail(detail *service.TransactionDetail) error {
 	date := time.Unix(detail.Timestamp, 0).Format("2005-01-02")
 	status := "Pending"
-	if detail.Status == 1 {
+	switch detail.Status {
+	case model.StatusCleared:
 		status = "Cleared"
+	case model.StatusReconciled:
+		status = "Reconciled"
+	}
+
+	if Structured() {
+		return renderTransactionDetailStructured(detail, status)
 	}
 
 	pterm.Println()
@@ -41,7 +51,7 @@ func RenderTransactionDetail(detail *service.TransactionDetail) error {
 	}
 
 	for _, split := range detail.Splits {
-		amountStr := utils.FormatFromCents(split.Amount)
+		amountStr := commodity.Format(split.Amount, split.Currency)
 		fullAmountStr := fmt.Sprintf("%s %s", amountStr, split.Currency)
 
 		typeStr := "Debit +"
@@ -49,7 +59,11 @@ func RenderTransactionDetail(detail *service.TransactionDetail) error {
 			typeStr = "Credit -"
 
 			absAmount := -split.Amount
-			fullAmountStr = fmt.Sprintf("%s %s", utils.FormatFromCents(absAmount), split.Currency)
+			fullAmountStr = fmt.Sprintf("%s %s", commodity.Format(absAmount, split.Currency), split.Currency)
+		}
+
+		if split.CostAmount != nil {
+			fullAmountStr += fmt.Sprintf(" (@@ %s %s)", commodity.Format(utils.AbsInt64(*split.CostAmount), split.CostCurrency), split.CostCurrency)
 		}
 
 		accountName := split.AccountName
@@ -80,3 +94,57 @@ func RenderTransactionDetail(detail *service.TransactionDetail) error {
 
 	return nil
 }
+
+type transactionDetailOutput struct {
+	ID          int64         `json:"id" yaml:"id"`
+	Date        string        `json:"date" yaml:"date"`
+	Description string        `json:"description" yaml:"description"`
+	Status      string        `json:"status" yaml:"status"`
+	Splits      []splitOutput `json:"splits" yaml:"splits"`
+}
+
+// splitOutput keeps the sign of the amount: debits are positive, credits
+// negative.
+type splitOutput struct {
+	ID           int64   `json:"id" yaml:"id"`
+	Account      string  `json:"account" yaml:"account"`
+	Amount       string  `json:"amount" yaml:"amount"`
+	Currency     string  `json:"currency" yaml:"currency"`
+	Memo         string  `json:"memo" yaml:"memo"`
+	CostAmount   *string `json:"cost_amount" yaml:"cost_amount"`
+	CostCurrency string  `json:"cost_currency" yaml:"cost_currency"`
+}
+
+func renderTransactionDetailStructured(detail *service.TransactionDetail, status string) error {
+	out := transactionDetailOutput{
+		ID:          detail.ID,
+		Date:        utils.FormatDate(detail.Timestamp),
+		Description: detail.Description,
+		Status:      status,
+		Splits:      make([]splitOutput, 0, len(detail.Splits)),
+	}
+	rows := make([][]string, 0, len(detail.Splits))
+
+	for _, split := range detail.Splits {
+		item := splitOutput{
+			ID:           split.ID,
+			Account:      split.AccountName,
+			Amount:       commodity.Format(split.Amount, split.Currency),
+			Currency:     split.Currency,
+			Memo:         split.Memo,
+			CostAmount:   formatOptional(split.CostAmount, split.CostCurrency),
+			CostCurrency: split.CostCurrency,
+		}
+		out.Splits = append(out.Splits, item)
+		rows = append(rows, []string{
+			strconv.FormatInt(out.ID, 10), out.Date, out.Description, out.Status,
+			strconv.FormatInt(item.ID, 10), item.Account, item.Amount, item.Currency, item.Memo,
+			csvOptional(item.CostAmount), item.CostCurrency,
+		})
+	}
+
+	return writeStructured(out, []string{
+		"id", "date", "description", "status",
+		"split_id", "account", "amount", "currency", "memo", "cost_amount", "cost_currency",
+	}, rows)
+}
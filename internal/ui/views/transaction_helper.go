@@ -3,8 +3,8 @@ package views
 import (
 	"fmt"
 
+	"github.com/hance08/kea/internal/commodity"
 	"github.com/hance08/kea/internal/service"
-	"github.com/hance08/kea/internal/utils"
 	"github.com/pterm/pterm"
 )
 
@@ -65,7 +65,7 @@ func RenderSimpleSplitList(splits []service.SplitDetail, txType service.Transact
 	roleLabels := GetSplitRoleLabels(splits, txType)
 
 	for i, split := range splits {
-		amount := utils.FormatFromCents(split.Amount)
+		amount := commodity.Format(split.Amount, split.Currency)
 		
 		sign := "+"
 		if split.Amount < 0 {
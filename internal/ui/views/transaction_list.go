@@ -2,7 +2,9 @@ package views
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/hance08/kea/internal/commodity"
 	"github.com/pterm/pterm"
 )
 
@@ -14,6 +16,10 @@ type TransactionListItem struct {
 	Description string
 	Amount      string
 	Status      string
+
+	// RawAmount and Currency are Amount before formatting, for structured output.
+	RawAmount int64
+	Currency  string
 }
 
 type TransactionListView struct{}
@@ -22,13 +28,23 @@ func NewTransactionListView() *TransactionListView {
 	return &TransactionListView{}
 }
 
-func (v *TransactionListView) Render(items []TransactionListItem, limit int) error {
+// Render lists one page of transactions, which starts after offset of the
+// total matching transactions.
+func (v *TransactionListView) Render(items []TransactionListItem, offset, total int) error {
+	if Structured() {
+		return v.renderStructured(items, offset, total)
+	}
+
 	if len(items) == 0 {
-		pterm.Warning.Println("No transactions found")
+		if total > 0 {
+			pterm.Warning.Printf("No transactions past %d, only %d found\n", offset, total)
+		} else {
+			pterm.Warning.Println("No transactions found")
+		}
 		return nil
 	}
 
-	pterm.DefaultSection.Printf("Showing recent transactions (limit: %d)", limit)
+	pterm.DefaultSection.Printf("Showing transactions %d-%d of %d", offset+1, offset+len(items), total)
 
 	tableData := pterm.TableData{
 		{"ID", "Date", "Type", "Account", "Description", "Amount", "Status"},
@@ -70,6 +86,50 @@ func (v *TransactionListView) Render(items []TransactionListItem, limit int) err
 	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
 		return err
 	}
-	pterm.Info.Printf("Total: %d transactions\n", len(items))
+	if offset+len(items) < total {
+		pterm.Info.Printf("%d more, use --offset %d to see the next page\n", total-offset-len(items), offset+len(items))
+	}
 	return nil
 }
+
+type transactionListOutput struct {
+	Offset       int                     `json:"offset" yaml:"offset"`
+	Total        int                     `json:"total" yaml:"total"`
+	Transactions []transactionListRecord `json:"transactions" yaml:"transactions"`
+}
+
+type transactionListRecord struct {
+	ID          int64  `json:"id" yaml:"id"`
+	Date        string `json:"date" yaml:"date"`
+	Type        string `json:"type" yaml:"type"`
+	Account     string `json:"account" yaml:"account"`
+	Description string `json:"description" yaml:"description"`
+	Amount      string `json:"amount" yaml:"amount"`
+	Currency    string `json:"currency" yaml:"currency"`
+	Status      string `json:"status" yaml:"status"`
+}
+
+func (v *TransactionListView) renderStructured(items []TransactionListItem, offset, total int) error {
+	out := transactionListOutput{Offset: offset, Total: total, Transactions: make([]transactionListRecord, 0, len(items))}
+	rows := make([][]string, 0, len(items))
+
+	for _, item := range items {
+		record := transactionListRecord{
+			ID:          item.ID,
+			Date:        item.Date,
+			Type:        item.Type,
+			Account:     item.Account,
+			Description: item.Description,
+			Amount:      commodity.Format(item.RawAmount, item.Currency),
+			Currency:    item.Currency,
+			Status:      item.Status,
+		}
+		out.Transactions = append(out.Transactions, record)
+		rows = append(rows, []string{
+			strconv.FormatInt(record.ID, 10), record.Date, record.Type, record.Account,
+			record.Description, record.Amount, record.Currency, record.Status,
+		})
+	}
+
+	return writeStructured(out, []string{"id", "date", "type", "account", "description", "amount", "currency", "status"}, rows)
+}
@@ -2,13 +2,23 @@ package views
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/hance08/kea/internal/commodity"
+	"github.com/hance08/kea/internal/model"
 	"github.com/hance08/kea/internal/service"
+	"github.com/hance08/kea/internal/utils"
 	"github.com/pterm/pterm"
 )
 
-func RenderTransactionSummary(input service.TransactionInput) error {
+// RenderTransactionSummary shows transaction txID as it was just created
+// from input.
+func RenderTransactionSummary(txID int64, input service.TransactionInput) error {
+	if Structured() {
+		return renderTransactionSummaryStructured(txID, input)
+	}
+
 	pterm.DefaultSection.Println("Transaction Summary")
 
 	date := time.Unix(input.Timestamp, 0).Format("2006-01-02")
@@ -35,28 +45,106 @@ func RenderTransactionSummary(input service.TransactionInput) error {
 		{"Account", "Amount", "Type"},
 	}
 
-	var total int64
+	totals := make(map[string]int64)
 	for _, split := range input.Splits {
-		// TODO: Change to currency package
-		amountStr := fmt.Sprintf("%.2f", float64(split.Amount)/100.0)
+		amountStr := commodity.Format(utils.AbsInt64(split.Amount), split.Currency)
 		typeStr := "Debit"
 		if split.Amount < 0 {
 			typeStr = "Credit"
-			amountStr = fmt.Sprintf("%.2f", float64(-split.Amount)/100.0)
+		}
+		if split.Currency != "" {
+			amountStr += " " + split.Currency
+		}
+		if split.CostAmount != nil {
+			amountStr += fmt.Sprintf(" (@@ %s %s)", commodity.Format(utils.AbsInt64(*split.CostAmount), split.CostCurrency), split.CostCurrency)
+			totals[split.CostCurrency] += *split.CostAmount
+		} else {
+			totals[split.Currency] += split.Amount
 		}
 		splitsData = append(splitsData, []string{split.AccountName, amountStr, typeStr})
-		total += split.Amount
 	}
 
 	if err := pterm.DefaultTable.WithHasHeader().WithData(splitsData).Render(); err != nil {
 		return err
 	}
 
-	if total == 0 {
+	balanced := true
+	for _, total := range totals {
+		if total != 0 {
+			balanced = false
+		}
+	}
+
+	if balanced {
 		pterm.Success.Println("✓ Splits balance verified (total = 0)")
 	} else {
-		pterm.Warning.Printf("⚠ Warning: Splits do not balance (total = %d)\n", total)
+		pterm.Warning.Printf("⚠ Warning: Splits do not balance (totals = %v)\n", totals)
 	}
 
 	return nil
 }
+
+type createdTransactionOutput struct {
+	ID          int64                `json:"id" yaml:"id"`
+	Date        string               `json:"date" yaml:"date"`
+	Description string               `json:"description" yaml:"description"`
+	Status      string               `json:"status" yaml:"status"`
+	Splits      []createdSplitOutput `json:"splits" yaml:"splits"`
+}
+
+// createdSplitOutput is a split as it was entered, signed like splitOutput.
+type createdSplitOutput struct {
+	Account      string  `json:"account" yaml:"account"`
+	Amount       string  `json:"amount" yaml:"amount"`
+	Currency     string  `json:"currency" yaml:"currency"`
+	Memo         string  `json:"memo" yaml:"memo"`
+	CostAmount   *string `json:"cost_amount" yaml:"cost_amount"`
+	CostCurrency string  `json:"cost_currency" yaml:"cost_currency"`
+}
+
+func newCreatedTransactionOutput(txID int64, input service.TransactionInput) createdTransactionOutput {
+	status := "Pending"
+	switch input.Status {
+	case model.StatusCleared:
+		status = "Cleared"
+	case model.StatusReconciled:
+		status = "Reconciled"
+	}
+
+	out := createdTransactionOutput{
+		ID:          txID,
+		Date:        utils.FormatDate(input.Timestamp),
+		Description: input.Description,
+		Status:      status,
+		Splits:      make([]createdSplitOutput, 0, len(input.Splits)),
+	}
+	for _, split := range input.Splits {
+		out.Splits = append(out.Splits, createdSplitOutput{
+			Account:      split.AccountName,
+			Amount:       commodity.Format(split.Amount, split.Currency),
+			Currency:     split.Currency,
+			Memo:         split.Memo,
+			CostAmount:   formatOptional(split.CostAmount, split.CostCurrency),
+			CostCurrency: split.CostCurrency,
+		})
+	}
+	return out
+}
+
+func renderTransactionSummaryStructured(txID int64, input service.TransactionInput) error {
+	out := newCreatedTransactionOutput(txID, input)
+	rows := make([][]string, 0, len(out.Splits))
+
+	for _, split := range out.Splits {
+		rows = append(rows, []string{
+			strconv.FormatInt(out.ID, 10), out.Date, out.Description, out.Status,
+			split.Account, split.Amount, split.Currency, split.Memo,
+			csvOptional(split.CostAmount), split.CostCurrency,
+		})
+	}
+
+	return writeStructured(out, []string{
+		"id", "date", "description", "status",
+		"account", "amount", "currency", "memo", "cost_amount", "cost_currency",
+	}, rows)
+}
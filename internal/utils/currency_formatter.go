@@ -2,49 +2,84 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
-
-	"github.com/hance08/kea/internal/constants"
 )
 
-func FormatFromCents(cents int64) string {
-	return fmt.Sprintf("%.2f", float64(cents)/float64(constants.CentsPerUnit))
+// FormatAmount formats an amount stored in minor units with the given number
+// of decimal places, e.g. 150050 with precision 2 is "1500.50".
+func FormatAmount(amount int64, precision int) string {
+	if precision <= 0 {
+		return strconv.FormatInt(amount, 10)
+	}
+
+	sign := ""
+	abs := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		abs = uint64(-amount)
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if len(digits) <= precision {
+		digits = strings.Repeat("0", precision-len(digits)+1) + digits
+	}
+
+	split := len(digits) - precision
+	return sign + digits[:split] + "." + digits[split:]
 }
 
-func ParseToCents(amountStr string) (int64, error) {
-	var dollars, cents int64
+// ParseAmount parses a decimal amount such as "-1500.5" into minor units.
+// Input with more decimal places than precision allows is rejected rather
+// than truncated.
+func ParseAmount(amountStr string, precision int) (int64, error) {
+	s := strings.TrimSpace(amountStr)
 
-	// Handle formats: "150", "150.5", "150.50"
-	parts := strings.Split(amountStr, ".")
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
 
-	if len(parts) > 2 {
-		return 0, fmt.Errorf("invalid amount format: %s", amountStr)
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("invalid amount: %s", amountStr)
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("invalid amount: %s", amountStr)
 	}
 
-	// Parse dollar part
-	if parts[0] != "" {
-		_, err := fmt.Sscanf(parts[0], "%d", &dollars)
-		if err != nil {
-			return 0, fmt.Errorf("invalid amount: %s", amountStr)
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > precision {
+		if precision == 0 {
+			return 0, fmt.Errorf("%s has decimal places, none are allowed", amountStr)
 		}
+		return 0, fmt.Errorf("%s has more than %d decimal places", amountStr, precision)
 	}
 
-	// Parse cents part if exists
-	if len(parts) == 2 {
-		centStr := parts[1]
-		// Pad or truncate to 2 digits
-		if len(centStr) == 1 {
-			centStr += "0" // "150.5" -> "50"
-		} else if len(centStr) > 2 {
-			centStr = centStr[:2] // Truncate extra digits
-		}
+	if whole == "" {
+		whole = "0"
+	}
+	digits := whole + frac + strings.Repeat("0", precision-len(frac))
 
-		_, err := fmt.Sscanf(centStr, "%d", &cents)
-		if err != nil {
-			return 0, fmt.Errorf("invalid cents: %s", amountStr)
-		}
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount out of range: %s", amountStr)
+	}
+
+	if negative {
+		value = -value
 	}
+	return value, nil
+}
 
-	total := dollars*int64(constants.CentsPerUnit) + cents
-	return total, nil
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
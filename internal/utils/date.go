@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hance08/kea/internal/constants"
+)
+
+// ParseDateStart returns the first second of a YYYY-MM-DD date.
+func ParseDateStart(dateStr string) (int64, error) {
+	t, err := time.Parse(constants.DateFormat, dateStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date format, use YYYY-MM-DD: %w", err)
+	}
+	return t.Unix(), nil
+}
+
+// ParseDateEnd returns the last second of a YYYY-MM-DD date, so transactions
+// dated on that day are included.
+func ParseDateEnd(dateStr string) (int64, error) {
+	t, err := time.Parse(constants.DateFormat, dateStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date format, use YYYY-MM-DD: %w", err)
+	}
+	return t.AddDate(0, 0, 1).Unix() - 1, nil
+}
+
+// FormatDate formats a transaction timestamp as YYYY-MM-DD. Dates are
+// stored as UTC midnight, so UTC is used to avoid shifting the day.
+func FormatDate(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format(constants.DateFormat)
+}
@@ -1,8 +1,49 @@
 package utils
 
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
 func AbsInt64(n int64) int64 {
 	if n < 0 {
 		return -n
 	}
 	return n
 }
+
+// ParseRate parses a positive exchange rate or price such as "1.0873".
+func ParseRate(rateStr string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(rateStr))
+	if !ok {
+		return nil, fmt.Errorf("invalid rate: %s", rateStr)
+	}
+	if rate.Sign() <= 0 {
+		return nil, fmt.Errorf("rate must be positive: %s", rateStr)
+	}
+	return rate, nil
+}
+
+// FormatRate formats a rate with up to six decimal places, e.g. "1.0873".
+func FormatRate(rate *big.Rat) string {
+	s := rate.FloatString(6)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// ApplyRate converts an amount in cents by rate, rounding half away from zero.
+func ApplyRate(cents int64, rate *big.Rat) int64 {
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(cents), rate)
+
+	num := new(big.Int).Abs(product.Num())
+	quo, rem := new(big.Int).QuoRem(num, product.Denom(), new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(product.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+
+	if product.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	return quo.Int64()
+}
@@ -69,7 +69,7 @@ func (v *AccountValidator) ValidateFullAccountName(fullName string) error {
 	return nil
 }
 
-// ValidateCurrency validates a currency code format
+// ValidateCurrency validates a currency code or ticker symbol format
 // Accepts both string and any (for survey compatibility)
 func (v *AccountValidator) ValidateCurrency(currency string) error {
 	currency = strings.TrimSpace(strings.ToUpper(currency))
@@ -78,13 +78,17 @@ func (v *AccountValidator) ValidateCurrency(currency string) error {
 		return nil // Empty is allowed (will use default)
 	}
 
-	if len(currency) != 3 {
-		return fmt.Errorf("currency code must be 3 characters (e.g. USD)")
+	if len(currency) > constants.MaxCommodityLen {
+		return fmt.Errorf("currency code too long (max %d characters, e.g. USD or AAPL)", constants.MaxCommodityLen)
+	}
+
+	if currency[0] < 'A' || currency[0] > 'Z' {
+		return fmt.Errorf("currency code must start with a letter")
 	}
 
 	for _, c := range currency {
-		if c < 'A' || c > 'Z' {
-			return fmt.Errorf("currency code must contain only letters")
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '.' && c != '_' && c != '-' {
+			return fmt.Errorf("currency code must contain only letters, digits, '.', '_' or '-'")
 		}
 	}
 
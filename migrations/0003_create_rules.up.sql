@@ -0,0 +1,14 @@
+-- Categorization rules pick the counter account for imported and quick-added transactions.
+-- All non-NULL conditions of a rule must match; rules are evaluated by priority (high first), then id.
+CREATE TABLE IF NOT EXISTS rules (
+    id                  INTEGER PRIMARY KEY AUTOINCREMENT,
+    priority            INTEGER NOT NULL DEFAULT 0,
+    description_pattern TEXT,                      -- regular expression matched against the description
+    min_amount          INTEGER,                   -- inclusive, in cents, compared with the absolute amount
+    max_amount          INTEGER,                   -- inclusive, in cents, compared with the absolute amount
+    source_account_id   INTEGER,                   -- only match transactions from/to this account
+    target_account_id   INTEGER NOT NULL,          -- counter account to use when the rule matches
+
+    FOREIGN KEY (source_account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (target_account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
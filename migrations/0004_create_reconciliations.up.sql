@@ -0,0 +1,12 @@
+-- Each row records one completed reconciliation session of an account against a bank statement.
+CREATE TABLE IF NOT EXISTS reconciliations (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_id      INTEGER NOT NULL,          -- point to accounts.id
+    statement_date  INTEGER NOT NULL,          -- statement date (Unix timestamp)
+    ending_balance  INTEGER NOT NULL,          -- statement ending balance in cents, stored sign
+    created_at      INTEGER NOT NULL,          -- when the session was completed (Unix timestamp)
+
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliations_account_id ON reconciliations (account_id);
@@ -0,0 +1,5 @@
+-- Value of a split in another currency, used to balance multi-currency
+-- transactions, e.g. 100.00 EUR bought for 108.73 USD.
+-- NULL means the split is balanced in its own currency.
+ALTER TABLE splits ADD COLUMN cost_amount INTEGER;
+ALTER TABLE splits ADD COLUMN cost_currency TEXT;
@@ -0,0 +1,12 @@
+-- Exchange rates and commodity prices, e.g. 1 EUR = 1.0873 USD on 2025-03-01
+CREATE TABLE IF NOT EXISTS prices (
+    id      INTEGER PRIMARY KEY AUTOINCREMENT,
+    date    INTEGER NOT NULL,   -- day the price was observed (Unix timestamp)
+    base    TEXT NOT NULL,      -- commodity being priced, e.g. EUR
+    quote   TEXT NOT NULL,      -- commodity the price is expressed in, e.g. USD
+    rate    TEXT NOT NULL,      -- units of quote per one base, kept as decimal text to stay exact
+
+    UNIQUE (date, base, quote)
+);
+
+CREATE INDEX IF NOT EXISTS idx_prices_pair_date ON prices (base, quote, date);
@@ -0,0 +1,54 @@
+-- Commodity registry: number of decimal places amounts are stored with.
+-- Codes that are not listed use 2 decimal places.
+CREATE TABLE IF NOT EXISTS commodities (
+    code        TEXT PRIMARY KEY,              -- e.g. "USD", "JPY", "BTC"
+    precision   INTEGER NOT NULL CHECK (precision BETWEEN 0 AND 8)
+);
+
+INSERT OR IGNORE INTO commodities (code, precision) VALUES
+    ('USD', 2), ('EUR', 2), ('GBP', 2), ('CHF', 2), ('CNY', 2), ('HKD', 2),
+    ('TWD', 2), ('SGD', 2), ('AUD', 2), ('CAD', 2),
+    ('JPY', 0), ('KRW', 0), ('VND', 0), ('CLP', 0), ('ISK', 0), ('PYG', 0),
+    ('KWD', 3), ('BHD', 3), ('OMR', 3), ('JOD', 3), ('TND', 3), ('LYD', 3), ('IQD', 3),
+    ('BTC', 8);
+
+-- Amounts used to be stored with 2 decimal places for every currency,
+-- rescale existing data of the currencies seeded with another precision.
+UPDATE splits SET amount = amount / 100
+    WHERE currency IN (SELECT code FROM commodities WHERE precision = 0);
+UPDATE splits SET amount = amount * 10
+    WHERE currency IN (SELECT code FROM commodities WHERE precision = 3);
+UPDATE splits SET amount = amount * 1000000
+    WHERE currency IN (SELECT code FROM commodities WHERE precision = 8);
+
+UPDATE splits SET cost_amount = cost_amount / 100
+    WHERE cost_currency IN (SELECT code FROM commodities WHERE precision = 0);
+UPDATE splits SET cost_amount = cost_amount * 10
+    WHERE cost_currency IN (SELECT code FROM commodities WHERE precision = 3);
+UPDATE splits SET cost_amount = cost_amount * 1000000
+    WHERE cost_currency IN (SELECT code FROM commodities WHERE precision = 8);
+
+UPDATE reconciliations SET ending_balance = ending_balance / 100
+    WHERE account_id IN (SELECT a.id FROM accounts a JOIN commodities c ON c.code = a.currency WHERE c.precision = 0);
+UPDATE reconciliations SET ending_balance = ending_balance * 10
+    WHERE account_id IN (SELECT a.id FROM accounts a JOIN commodities c ON c.code = a.currency WHERE c.precision = 3);
+UPDATE reconciliations SET ending_balance = ending_balance * 1000000
+    WHERE account_id IN (SELECT a.id FROM accounts a JOIN commodities c ON c.code = a.currency WHERE c.precision = 8);
+
+-- Rule amounts are in the currency of their source account, or in the default
+-- currency without one, which Equity:OpeningBalances is created in.
+UPDATE rules SET min_amount = min_amount / 100, max_amount = max_amount / 100
+    WHERE COALESCE(
+        (SELECT currency FROM accounts WHERE id = rules.source_account_id),
+        (SELECT currency FROM accounts WHERE name = 'Equity:OpeningBalances')
+    ) IN (SELECT code FROM commodities WHERE precision = 0);
+UPDATE rules SET min_amount = min_amount * 10, max_amount = max_amount * 10
+    WHERE COALESCE(
+        (SELECT currency FROM accounts WHERE id = rules.source_account_id),
+        (SELECT currency FROM accounts WHERE name = 'Equity:OpeningBalances')
+    ) IN (SELECT code FROM commodities WHERE precision = 3);
+UPDATE rules SET min_amount = min_amount * 1000000, max_amount = max_amount * 1000000
+    WHERE COALESCE(
+        (SELECT currency FROM accounts WHERE id = rules.source_account_id),
+        (SELECT currency FROM accounts WHERE name = 'Equity:OpeningBalances')
+    ) IN (SELECT code FROM commodities WHERE precision = 8);
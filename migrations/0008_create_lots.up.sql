@@ -0,0 +1,33 @@
+-- Investment lots: each buy of a commodity opens a lot that later sales are matched against
+CREATE TABLE IF NOT EXISTS lots (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    account_id      INTEGER NOT NULL,          -- point to accounts.id, the account holding the commodity
+    split_id        INTEGER NOT NULL UNIQUE,   -- point to splits.id, the split that bought the lot
+    date            INTEGER NOT NULL,          -- acquisition date (Unix timestamp)
+    quantity        INTEGER NOT NULL,          -- units bought, in minor units of the account's commodity
+    cost_amount     INTEGER NOT NULL,          -- total cost of quantity, in minor units of cost_currency
+    cost_currency   TEXT NOT NULL,
+
+    -- deleting the buy transaction removes its lots
+    FOREIGN KEY (split_id) REFERENCES splits(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_lots_account_id ON lots (account_id);
+
+-- Each row records the part of a lot consumed by one sale
+CREATE TABLE IF NOT EXISTS lot_disposals (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    lot_id          INTEGER NOT NULL,          -- point to lots.id
+    split_id        INTEGER NOT NULL,          -- point to splits.id, the split that sold the units
+    quantity        INTEGER NOT NULL,          -- units taken from the lot
+    cost_amount     INTEGER NOT NULL,          -- cost basis of those units, in the lot's cost currency
+
+    -- deleting the sale puts the units back into the lot,
+    -- while a lot that has been sold from cannot be deleted
+    FOREIGN KEY (split_id) REFERENCES splits(id) ON DELETE CASCADE,
+    FOREIGN KEY (lot_id) REFERENCES lots(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_lot_disposals_lot_id ON lot_disposals (lot_id);
+CREATE INDEX IF NOT EXISTS idx_lot_disposals_split_id ON lot_disposals (split_id);
@@ -0,0 +1,40 @@
+-- Scheduled transactions are templates posted on every occurrence of their recurrence
+CREATE TABLE IF NOT EXISTS scheduled_transactions (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    description     TEXT NOT NULL,
+    frequency       TEXT NOT NULL,             -- daily, weekly, monthly or yearly
+    interval        INTEGER NOT NULL,          -- every n days, weeks, months or years
+    day_of_month    INTEGER,                   -- monthly and yearly only, clamped to the end of short months; NULL uses the day of start_date
+    start_date      INTEGER NOT NULL,          -- first possible occurrence (Unix timestamp)
+    end_date        INTEGER,                   -- last possible occurrence (Unix timestamp), NULL repeats forever
+    auto_post       INTEGER NOT NULL           -- 1=post as cleared, 0=create as pending
+);
+
+-- The splits of the transaction each occurrence posts
+CREATE TABLE IF NOT EXISTS scheduled_splits (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    schedule_id     INTEGER NOT NULL,          -- point to scheduled_transactions.id
+    account_id      INTEGER NOT NULL,          -- point to accounts.id
+    amount          INTEGER NOT NULL,          -- in minor units of currency, debits positive
+    currency        TEXT NOT NULL,
+    memo            TEXT NOT NULL DEFAULT '',
+    cost_amount     INTEGER,                   -- as in splits
+    cost_currency   TEXT,
+
+    FOREIGN KEY (schedule_id) REFERENCES scheduled_transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_splits_schedule_id ON scheduled_splits (schedule_id);
+
+-- Each occurrence that has been posted, so that it is never posted twice
+CREATE TABLE IF NOT EXISTS scheduled_postings (
+    schedule_id     INTEGER NOT NULL,          -- point to scheduled_transactions.id
+    occurrence      INTEGER NOT NULL,          -- date of the occurrence (Unix timestamp)
+    transaction_id  INTEGER,                   -- point to transactions.id, NULL once that transaction is deleted
+
+    PRIMARY KEY (schedule_id, occurrence),
+    -- deleting the posted transaction keeps the occurrence posted
+    FOREIGN KEY (schedule_id) REFERENCES scheduled_transactions(id) ON DELETE CASCADE,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE SET NULL
+);